@@ -0,0 +1,291 @@
+package luhn
+
+import (
+	"crypto/subtle"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// modNMaxLength is the exclusive upper bound on input length for the mod-N
+// functions.
+const modNMaxLength = 10000
+
+// Alphabet is an ordered set of characters for the Luhn mod-N algorithm,
+// where N is the size of the alphabet. A character's value is its position in
+// the alphabet, and check characters are rendered from the alphabet.
+// Characters may be any Unicode runes; input is processed rune by rune.
+//
+// Create an Alphabet with NewAlphabet or use a predefined one. The zero
+// Alphabet has no characters: Generate, Validate, Checksum and Suggest return
+// ErrEmptyAlphabet, and a Checksummer over it rejects every character.
+type Alphabet struct {
+	chars    string
+	runes    []rune
+	ascii    [utf8.RuneSelf]int16
+	extra    map[rune]int
+	foldCase bool
+}
+
+// Predefined alphabets.
+var (
+	// Base36 is the CODE_POINTS alphabet 0-9A-Z used by GenerateModN with
+	// n=36. Lowercase input is accepted.
+	Base36 = mustAlphabet(codePoints, true)
+	// Crockford32 is Douglas Crockford's base32 alphabet, which omits I, L, O
+	// and U to avoid confusion. Lowercase input is accepted.
+	Crockford32 = mustAlphabet("0123456789ABCDEFGHJKMNPQRSTVWXYZ", true)
+	// Base58 is the Bitcoin base58 alphabet, which omits 0, O, I and l.
+	// It is case-sensitive.
+	Base58 = mustAlphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz", false)
+)
+
+// codePointAlphabets[n] holds the first n characters of CODE_POINTS with case
+// folding, backing GenerateModN, ValidateModN and ChecksumModN.
+var codePointAlphabets = func() [37]*Alphabet {
+	var a [37]*Alphabet
+	for n := 1; n <= 36; n++ {
+		a[n] = mustAlphabet(codePoints[:n], true)
+	}
+	return a
+}()
+
+// NewAlphabet returns an Alphabet of the given characters, in order. If
+// foldCase is true, letters are matched case-insensitively (using Unicode
+// simple case mapping) and each letter may appear only once regardless of
+// case. Returns an error if chars is empty, or contains a space, invalid
+// UTF-8, or a duplicate character.
+func NewAlphabet(chars string, foldCase bool) (*Alphabet, error) {
+	if chars == "" {
+		return nil, newValidationError("NewAlphabet", ReasonEmpty, ErrEmpty, chars, -1)
+	}
+	a := &Alphabet{chars: chars, foldCase: foldCase}
+	for i := range a.ascii {
+		a.ascii[i] = -1
+	}
+	for i, r := range chars {
+		if r == ' ' {
+			return nil, newValidationError("NewAlphabet", ReasonSpaces, ErrSpaces, chars, i)
+		}
+		if r == utf8.RuneError {
+			return nil, newValidationError("NewAlphabet", ReasonInvalidCharacter, ErrInvalidCharacter, chars, i)
+		}
+		keys := a.keys(r)
+		for _, k := range keys {
+			if a.IndexRune(k) >= 0 {
+				return nil, newValidationError("NewAlphabet", ReasonDuplicate, ErrDuplicateChar, chars, i)
+			}
+		}
+		for _, k := range keys {
+			if k < utf8.RuneSelf {
+				a.ascii[k] = int16(len(a.runes))
+				continue
+			}
+			if a.extra == nil {
+				a.extra = make(map[rune]int)
+			}
+			a.extra[k] = len(a.runes)
+		}
+		a.runes = append(a.runes, r)
+	}
+	return a, nil
+}
+
+// mustAlphabet is like NewAlphabet but panics on error. It is used for the
+// predefined alphabets.
+func mustAlphabet(chars string, foldCase bool) *Alphabet {
+	a, err := NewAlphabet(chars, foldCase)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// keys returns the runes that map to r in the index.
+func (a *Alphabet) keys(r rune) []rune {
+	keys := []rune{r}
+	if a.foldCase {
+		for _, k := range []rune{unicode.ToUpper(r), unicode.ToLower(r)} {
+			if k != r {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// Len returns the number of characters in the alphabet, i.e. N.
+func (a *Alphabet) Len() int {
+	return len(a.runes)
+}
+
+// String returns the characters of the alphabet in order.
+func (a *Alphabet) String() string {
+	return a.chars
+}
+
+// FoldCase reports whether the alphabet matches letters case-insensitively.
+func (a *Alphabet) FoldCase() bool {
+	return a.foldCase
+}
+
+// Index returns the position of the ASCII character c in the alphabet, or -1
+// if c is not in it. Bytes outside the ASCII range always return -1; use
+// IndexRune for other characters.
+func (a *Alphabet) Index(c byte) int {
+	if c >= utf8.RuneSelf || a.runes == nil {
+		return -1
+	}
+	return int(a.ascii[c])
+}
+
+// IndexRune returns the position of r in the alphabet, or -1 if r is not in it.
+func (a *Alphabet) IndexRune(r rune) int {
+	if a.runes == nil {
+		return -1
+	}
+	if r >= 0 && r < utf8.RuneSelf {
+		return int(a.ascii[r])
+	}
+	if idx, ok := a.extra[r]; ok {
+		return idx
+	}
+	return -1
+}
+
+// isASCII reports whether every character of the alphabet is ASCII, allowing
+// byte-wise processing of valid input.
+func (a *Alphabet) isASCII() bool {
+	return a.extra == nil
+}
+
+// validateInput validates input for the alphabet's mod-N functions and
+// returns its length in characters. Rejects the zero Alphabet, checks empty
+// and spaces (shared with the base validation), then validates each character
+// against the alphabet. fn
+// names the public function reported in any returned *ValidationError.
+func (a *Alphabet) validateInput(fn, value string) (int, error) {
+	if a.runes == nil {
+		return 0, newValidationError(fn, ReasonEmpty, ErrEmptyAlphabet, value, -1)
+	}
+	if value == "" {
+		return 0, newValidationError(fn, ReasonEmpty, ErrEmpty, value, -1)
+	}
+	if i := strings.IndexByte(value, ' '); i >= 0 {
+		return 0, newValidationError(fn, ReasonSpaces, ErrSpaces, value, i)
+	}
+	count := 0
+	for i, r := range value {
+		// Invalid UTF-8 decodes to utf8.RuneError, which is never in the alphabet.
+		if a.IndexRune(r) < 0 {
+			return 0, newValidationError(fn, ReasonInvalidCharacter, ErrInvalidCharacter, value, i)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// contribution returns the amount idx adds to a Luhn mod-n sum, doubling it
+// if double is true.
+func contribution(idx, n int, double bool) int {
+	if !double {
+		return idx
+	}
+	doubled := idx * 2
+	if doubled >= n {
+		return doubled/n + doubled%n
+	}
+	return doubled
+}
+
+// checksum computes the Luhn mod-N check character index.
+// value must already have passed validateInput.
+func (a *Alphabet) checksum(value string) int {
+	n := len(a.runes)
+	sum := 0
+	shouldDouble := true
+
+	if a.isASCII() {
+		for i := len(value) - 1; i >= 0; i-- {
+			sum += contribution(int(a.ascii[value[i]]), n, shouldDouble)
+			shouldDouble = !shouldDouble
+		}
+	} else {
+		for i := len(value); i > 0; {
+			r, size := utf8.DecodeLastRuneInString(value[:i])
+			sum += contribution(a.IndexRune(r), n, shouldDouble)
+			shouldDouble = !shouldDouble
+			i -= size
+		}
+	}
+
+	return (n - (sum % n)) % n
+}
+
+// Generate computes a Luhn mod-N check character for value over the alphabet.
+// If checksumOnly is true, only the check character is returned.
+func (a *Alphabet) Generate(value string, checksumOnly bool) (string, error) {
+	return a.generate("Alphabet.Generate", value, checksumOnly)
+}
+
+// generate implements Alphabet.Generate, reporting errors as coming from fn.
+func (a *Alphabet) generate(fn, value string, checksumOnly bool) (string, error) {
+	count, err := a.validateInput(fn, value)
+	if err != nil {
+		return "", err
+	}
+	if count >= modNMaxLength {
+		return "", newValidationError(fn, ReasonTooLong, ErrModNMaxLength, value, -1)
+	}
+
+	checkChar := string(a.runes[a.checksum(value)])
+	if checksumOnly {
+		return checkChar, nil
+	}
+	return value + checkChar, nil
+}
+
+// Validate determines whether value has a valid Luhn mod-N check character
+// over the alphabet.
+func (a *Alphabet) Validate(value string) (bool, error) {
+	return a.validate("Alphabet.Validate", value)
+}
+
+// validate implements Alphabet.Validate, reporting errors as coming from fn.
+func (a *Alphabet) validate(fn, value string) (bool, error) {
+	count, err := a.validateInput(fn, value)
+	if err != nil {
+		return false, err
+	}
+	if count == 1 {
+		return false, newValidationError(fn, ReasonTooShort, ErrMinLength, value, -1)
+	}
+	if count >= modNMaxLength {
+		return false, newValidationError(fn, ReasonTooLong, ErrModNMaxLength, value, -1)
+	}
+
+	check, size := utf8.DecodeLastRuneInString(value)
+	want := a.checksum(value[:len(value)-size])
+	// Use constant-time comparison to prevent timing side-channel attacks
+	// that could reveal information about valid check characters. Comparing
+	// indexes rather than bytes also honours case folding.
+	return subtle.ConstantTimeEq(int32(want), int32(a.IndexRune(check))) == 1, nil
+}
+
+// Checksum returns the index of the Luhn mod-N check character for value.
+func (a *Alphabet) Checksum(value string) (int, error) {
+	return a.checksumChecked("Alphabet.Checksum", value)
+}
+
+// checksumChecked implements Alphabet.Checksum, reporting errors as coming
+// from fn.
+func (a *Alphabet) checksumChecked(fn, value string) (int, error) {
+	count, err := a.validateInput(fn, value)
+	if err != nil {
+		return 0, err
+	}
+	if count >= modNMaxLength {
+		return 0, newValidationError(fn, ReasonTooLong, ErrModNMaxLength, value, -1)
+	}
+	return a.checksum(value), nil
+}
@@ -0,0 +1,295 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestNewAlphabetErrors tests alphabet construction errors.
+func TestNewAlphabetErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		chars    string
+		foldCase bool
+		want     error
+		index    int
+	}{
+		{"empty", "", false, luhn.ErrEmpty, -1},
+		{"space", "AB C", false, luhn.ErrSpaces, 2},
+		{"invalid UTF-8", "AB\xff", false, luhn.ErrInvalidCharacter, 2},
+		{"duplicate", "ABCA", false, luhn.ErrDuplicateChar, 3},
+		{"duplicate when folding", "ABCa", true, luhn.ErrDuplicateChar, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := luhn.NewAlphabet(tt.chars, tt.foldCase)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			var verr *luhn.ValidationError
+			if !errors.As(err, &verr) || verr.Index != tt.index {
+				t.Errorf("got %#v, want Index %d", err, tt.index)
+			}
+		})
+	}
+
+	if _, err := luhn.NewAlphabet("ABCa", false); err != nil {
+		t.Errorf("case-sensitive alphabet with both cases: unexpected error %v", err)
+	}
+}
+
+// TestAlphabetZeroValue tests that the zero Alphabet returns errors instead
+// of panicking.
+func TestAlphabetZeroValue(t *testing.T) {
+	var a luhn.Alphabet
+	if _, err := a.Generate("1", false); !errors.Is(err, luhn.ErrEmptyAlphabet) {
+		t.Errorf("Generate: got %v, want ErrEmptyAlphabet", err)
+	}
+	if _, err := a.Validate("10"); !errors.Is(err, luhn.ErrEmptyAlphabet) {
+		t.Errorf("Validate: got %v, want ErrEmptyAlphabet", err)
+	}
+	if _, err := a.Checksum("1"); !errors.Is(err, luhn.ErrEmptyAlphabet) {
+		t.Errorf("Checksum: got %v, want ErrEmptyAlphabet", err)
+	}
+	if _, err := a.Suggest("10"); !errors.Is(err, luhn.ErrEmptyAlphabet) {
+		t.Errorf("Suggest: got %v, want ErrEmptyAlphabet", err)
+	}
+	if a.Index('0') != -1 || a.IndexRune('0') != -1 {
+		t.Error("zero Alphabet contains '0'")
+	}
+	if _, err := luhn.NewChecksummerAlphabet(&a).Write([]byte("1")); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("Checksummer: got %v, want ErrInvalidCharacter", err)
+	}
+}
+
+// TestAlphabetMatchesModN verifies that a CODE_POINTS alphabet agrees with the mod-N functions.
+func TestAlphabetMatchesModN(t *testing.T) {
+	inputs := []string{"1", "HELLO", "hello", "123ABC", "ZZZZ"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			want, err := luhn.GenerateModN(input, 36, false)
+			if err != nil {
+				t.Fatalf("GenerateModN error: %v", err)
+			}
+			got, err := luhn.Base36.Generate(input, false)
+			if err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+			if got != want {
+				t.Errorf("Base36.Generate(%q) = %q, want %q", input, got, want)
+			}
+
+			wantIdx, _ := luhn.ChecksumModN(input, 36)
+			gotIdx, err := luhn.Base36.Checksum(input)
+			if err != nil {
+				t.Fatalf("Checksum error: %v", err)
+			}
+			if gotIdx != wantIdx {
+				t.Errorf("Base36.Checksum(%q) = %d, want %d", input, gotIdx, wantIdx)
+			}
+		})
+	}
+}
+
+// TestAlphabetRoundTrip tests Generate/Validate round trips on the predefined alphabets.
+func TestAlphabetRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet *luhn.Alphabet
+		input    string
+	}{
+		{"Crockford32", luhn.Crockford32, "7ZXM3Q"},
+		{"Crockford32 lowercase", luhn.Crockford32, "7zxm3q"},
+		{"Base58", luhn.Base58, "3yQzaB9"},
+		{"Base58 single", luhn.Base58, "z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generated, err := tt.alphabet.Generate(tt.input, false)
+			if err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+			valid, err := tt.alphabet.Validate(generated)
+			if err != nil {
+				t.Fatalf("Validate error: %v", err)
+			}
+			if !valid {
+				t.Errorf("Validate(%q) = false, want true", generated)
+			}
+		})
+	}
+}
+
+// TestAlphabetLarge verifies that alphabets beyond 36 characters are supported.
+func TestAlphabetLarge(t *testing.T) {
+	if n := luhn.Base58.Len(); n != 58 {
+		t.Fatalf("Base58.Len() = %d, want 58", n)
+	}
+	// "z" is the last character (index 57) so exercises the upper range.
+	idx, err := luhn.Base58.Checksum("zz")
+	if err != nil {
+		t.Fatalf("Checksum error: %v", err)
+	}
+	// Doubled 57 -> 114 -> 114/58 + 114%58 = 1 + 56 = 57; sum 57 + 57 = 114;
+	// check = (58 - 114%58) % 58 = 2.
+	if idx != 2 {
+		t.Errorf("Base58.Checksum(\"zz\") = %d, want 2", idx)
+	}
+}
+
+// TestAlphabetCase verifies case folding and case sensitivity.
+func TestAlphabetCase(t *testing.T) {
+	generated, _ := luhn.Crockford32.Generate("ABC", false)
+	lowered := []byte(generated)
+	for i := range lowered {
+		if lowered[i] >= 'A' && lowered[i] <= 'Z' {
+			lowered[i] += 'a' - 'A'
+		}
+	}
+	if valid, err := luhn.Crockford32.Validate(string(lowered)); err != nil || !valid {
+		t.Errorf("Crockford32.Validate(%q) = %v, %v, want true", lowered, valid, err)
+	}
+
+	// Crockford's alphabet excludes I, L, O and U.
+	if _, err := luhn.Crockford32.Validate("AIB"); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("Crockford32.Validate(\"AIB\"): got %v, want %v", err, luhn.ErrInvalidCharacter)
+	}
+	// Base58 is case-sensitive: 'a' and 'A' have different values.
+	if luhn.Base58.Index('a') == luhn.Base58.Index('A') {
+		t.Error("Base58 should distinguish 'a' and 'A'")
+	}
+	if luhn.Base58.Index('0') != -1 {
+		t.Error("Base58 should not contain '0'")
+	}
+}
+
+// TestAlphabetSuggest verifies Alphabet.Suggest on a case-sensitive alphabet.
+func TestAlphabetSuggest(t *testing.T) {
+	valid, _ := luhn.Base58.Generate("3yQzaB9", false)
+	b := []byte(valid)
+	b[2], b[3] = b[3], b[2]
+	got, err := luhn.Base58.Suggest(string(b))
+	if err != nil {
+		t.Fatalf("Suggest error: %v", err)
+	}
+	for _, s := range got {
+		if s.Value == valid {
+			return
+		}
+	}
+	t.Errorf("Suggest(%q) = %+v, want it to contain %q", b, got, valid)
+}
+
+// TestChecksummerAlphabet verifies that a Checksummer over a custom alphabet agrees with Checksum.
+func TestChecksummerAlphabet(t *testing.T) {
+	want, _ := luhn.Base58.Checksum("3yQzaB9")
+	c := luhn.NewChecksummerAlphabet(luhn.Base58)
+	if _, err := c.Write([]byte("3yQzaB9")); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if got := c.Sum(); got != want {
+		t.Errorf("Sum = %d, want %d", got, want)
+	}
+}
+
+// TestRuneAlphabet tests mod-N generation and validation over non-Latin alphabets.
+func TestRuneAlphabet(t *testing.T) {
+	hiragana, err := luhn.NewAlphabet("あいうえおかきくけこさしすせそたちつてとなにぬねの", false)
+	if err != nil {
+		t.Fatalf("NewAlphabet error: %v", err)
+	}
+	cyrillic, err := luhn.NewAlphabet("АБВГДЕЖЗИКЛМНОПРСТУФХЦЧШЭЮЯ", true)
+	if err != nil {
+		t.Fatalf("NewAlphabet error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		alphabet *luhn.Alphabet
+		input    string
+	}{
+		{"hiragana", hiragana, "ねこのて"},
+		{"cyrillic", cyrillic, "МОСКВА"},
+		{"cyrillic lowercase", cyrillic, "москва"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generated, err := tt.alphabet.Generate(tt.input, false)
+			if err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+			if got, want := utf8.RuneCountInString(generated), utf8.RuneCountInString(tt.input)+1; got != want {
+				t.Errorf("Generate(%q) has %d runes, want %d", tt.input, got, want)
+			}
+			valid, err := tt.alphabet.Validate(generated)
+			if err != nil {
+				t.Fatalf("Validate error: %v", err)
+			}
+			if !valid {
+				t.Errorf("Validate(%q) = false, want true", generated)
+			}
+
+			// Streaming one byte at a time must agree, even though each
+			// character spans several bytes.
+			want, _ := tt.alphabet.Checksum(tt.input)
+			c := luhn.NewChecksummerAlphabet(tt.alphabet)
+			for i := 0; i < len(tt.input); i++ {
+				if err := c.WriteByte(tt.input[i]); err != nil {
+					t.Fatalf("WriteByte error: %v", err)
+				}
+			}
+			if got := c.Sum(); got != want {
+				t.Errorf("Checksummer.Sum() = %d, want %d", got, want)
+			}
+		})
+	}
+
+	if cyrillic.Len() != 27 {
+		t.Errorf("Len() = %d, want 27", cyrillic.Len())
+	}
+	if cyrillic.IndexRune('б') != 1 {
+		t.Errorf("IndexRune('б') = %d, want 1", cyrillic.IndexRune('б'))
+	}
+}
+
+// TestRuneAlphabetErrors verifies that invalid runes are reported with their rune offset.
+func TestRuneAlphabetErrors(t *testing.T) {
+	cyrillic, _ := luhn.NewAlphabet("АБВГДЕЖЗИКЛМНОПРСТУФХЦЧШЭЮЯ", false)
+
+	_, err := cyrillic.Validate("МОЁКВА")
+	var verr *luhn.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Fatalf("got %v, want ErrInvalidCharacter", err)
+	}
+	if verr.Rune != 'Ё' || verr.RuneIndex != 2 || verr.Index != 4 {
+		t.Errorf("Rune, RuneIndex, Index = %q, %d, %d, want 'Ё', 2, 4", verr.Rune, verr.RuneIndex, verr.Index)
+	}
+	if want := "invalid character: 'Ё'"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	// Case-sensitive alphabet rejects lowercase.
+	if _, err := cyrillic.Validate("мо"); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("got %v, want ErrInvalidCharacter", err)
+	}
+	// Length checks count runes, not bytes.
+	if _, err := cyrillic.Validate("М"); !errors.Is(err, luhn.ErrMinLength) {
+		t.Errorf("got %v, want ErrMinLength", err)
+	}
+
+	c := luhn.NewChecksummerAlphabet(cyrillic)
+	n, err := c.Write([]byte("МОЁ"))
+	if !errors.As(err, &verr) {
+		t.Fatalf("got %v, want *ValidationError", err)
+	}
+	if n != 4 || verr.Rune != 'Ё' || verr.RuneIndex != 2 || verr.Index != 4 {
+		t.Errorf("n, Rune, RuneIndex, Index = %d, %q, %d, %d, want 4, 'Ё', 2, 4", n, verr.Rune, verr.RuneIndex, verr.Index)
+	}
+}
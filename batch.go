@@ -0,0 +1,187 @@
+package luhn
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// maxUint64Digits is the largest number of random digits whose keyspace
+// (10^maxUint64Digits) fits in a uint64.
+const maxUint64Digits = 19
+
+// maxSeenHint caps the initial size of the uniqueness set so that a large
+// count does not allocate everything up front.
+const maxSeenHint = 1 << 20
+
+// BatchOptions configures RandomBatch.
+type BatchOptions struct {
+	// Prefix fixes the leading digits of every number, as in
+	// RandomWithPrefix. If empty, the first digit is 1-9 as in Random.
+	Prefix string
+	// Generator supplies the randomness. If nil, crypto/rand is used.
+	Generator *Generator
+}
+
+// RandomBatch generates count distinct random numeric strings of the given
+// length with valid Luhn check digits and passes each one to yield as soon as
+// it is generated. It stops early and returns the error if yield returns a
+// non-nil error or ctx is cancelled.
+//
+// Returns ErrKeyspace without generating anything if count exceeds the number
+// of distinct values available for length and opts.Prefix.
+func RandomBatch(ctx context.Context, count, length int, opts BatchOptions, yield func(string) error) error {
+	return randomBatch(ctx, "RandomBatch", count, length, opts, yield)
+}
+
+// RandomBatchChan is like RandomBatch but delivers the numbers on a channel,
+// which is closed once generation finishes. The error channel then receives
+// the error that stopped generation, if any, and is closed. Cancel ctx to stop
+// generation early; the string channel is unbuffered, so the caller must
+// drain it or cancel ctx.
+func RandomBatchChan(ctx context.Context, count, length int, opts BatchOptions) (<-chan string, <-chan error) {
+	out := make(chan string)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		err := randomBatch(ctx, "RandomBatchChan", count, length, opts, func(s string) error {
+			select {
+			case out <- s:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		close(out)
+		if err != nil {
+			errc <- err
+		}
+	}()
+	return out, errc
+}
+
+// randomBatch implements RandomBatch, reporting errors as coming from fn.
+func randomBatch(ctx context.Context, fn string, count, length int, opts BatchOptions, yield func(string) error) error {
+	if opts.Prefix != "" {
+		if err := checkPrefix(fn, opts.Prefix, length); err != nil {
+			return err
+		}
+	} else if err := checkRandomLength(fn, length); err != nil {
+		return err
+	}
+	if count < 0 {
+		return newValidationError(fn, ReasonOutOfRange, ErrInvalidCount, "", -1)
+	}
+
+	g := opts.Generator
+	if g == nil {
+		g = defaultGenerator
+	}
+	b := &sampler{g: g}
+
+	// Positions between the prefix and the check digit are random. Without a
+	// prefix the first of them must be non-zero.
+	random := length - 1 - len(opts.Prefix)
+	buf := make([]byte, length-1, length)
+	copy(buf, opts.Prefix)
+	digits := buf[len(opts.Prefix):]
+
+	if random > maxUint64Digits {
+		// The keyspace exceeds any int count, so only uniqueness matters.
+		seen := make(map[string]struct{}, min(count, maxSeenHint))
+		for len(seen) < count {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := b.digits(digits, opts.Prefix == ""); err != nil {
+				return err
+			}
+			if _, dup := seen[string(digits)]; dup {
+				continue
+			}
+			seen[string(digits)] = struct{}{}
+			if err := yield(string(append(buf, generateChecksum(buf)))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Draw the random positions as an integer in [low, low+size).
+	var low uint64
+	size := pow10(random)
+	if opts.Prefix == "" {
+		low = pow10(random - 1)
+		size -= low
+	}
+	if uint64(count) > size {
+		return newValidationError(fn, ReasonOutOfRange, ErrKeyspace, "", -1)
+	}
+
+	seen := make(map[uint64]struct{}, min(count, maxSeenHint))
+	for len(seen) < count {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		x, err := b.uniform(size)
+		if err != nil {
+			return err
+		}
+		if _, dup := seen[x]; dup {
+			continue
+		}
+		seen[x] = struct{}{}
+		putDigits(digits, low+x)
+		if err := yield(string(append(buf, generateChecksum(buf)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pow10 returns 10^n for 0 <= n <= maxUint64Digits.
+func pow10(n int) uint64 {
+	p := uint64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+// putDigits writes v into buf as zero-padded decimal digits.
+func putDigits(buf []byte, v uint64) {
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+}
+
+// sampler draws uniform values from the source of a Generator, avoiding the
+// per-digit big.Int allocations of rand.Int. It reads only the bytes it uses,
+// so later draws from the Generator do not depend on how it consumed its
+// source.
+type sampler struct {
+	g   *Generator
+	buf [8]byte
+}
+
+// uniform returns a uniformly distributed value in [0, n) using rejection
+// sampling. n must be non-zero.
+func (b *sampler) uniform(n uint64) (uint64, error) {
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if _, err := io.ReadFull(b.g.r, b.buf[:]); err != nil {
+			return 0, err
+		}
+		if v := binary.LittleEndian.Uint64(b.buf[:]); v < limit {
+			return v % n, nil
+		}
+	}
+}
+
+// digits fills buf with uniformly distributed random digits. If nonZero is
+// true the first digit is drawn from 1-9.
+func (b *sampler) digits(buf []byte, nonZero bool) error {
+	return b.g.fillDigits(buf, nonZero)
+}
@@ -0,0 +1,198 @@
+package luhn_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestRandomBatchUnique verifies that RandomBatch yields count distinct valid numbers.
+func TestRandomBatchUnique(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int
+		length int
+		opts   luhn.BatchOptions
+	}{
+		{"length 16", 1000, 16, luhn.BatchOptions{}},
+		{"whole keyspace", 9, 2, luhn.BatchOptions{}},
+		{"prefix", 100, 7, luhn.BatchOptions{Prefix: "4111"}},
+		{"prefix fills payload", 1, 5, luhn.BatchOptions{Prefix: "4111"}},
+		{"beyond uint64", 50, 40, luhn.BatchOptions{Prefix: "35"}},
+		{"beyond uint64 no prefix", 50, 40, luhn.BatchOptions{}},
+		{"seeded", 100, 16, luhn.BatchOptions{Generator: luhn.NewSeededGenerator(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[string]bool)
+			err := luhn.RandomBatch(context.Background(), tt.count, tt.length, tt.opts, func(s string) error {
+				if seen[s] {
+					t.Fatalf("duplicate value %q", s)
+				}
+				seen[s] = true
+				if len(s) != tt.length {
+					t.Errorf("len(%q) = %d, want %d", s, len(s), tt.length)
+				}
+				if !strings.HasPrefix(s, tt.opts.Prefix) {
+					t.Errorf("%q does not start with %q", s, tt.opts.Prefix)
+				}
+				if tt.opts.Prefix == "" && s[0] == '0' {
+					t.Errorf("%q starts with zero", s)
+				}
+				if valid, err := luhn.Validate(s); err != nil || !valid {
+					t.Errorf("%q fails Validate", s)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(seen) != tt.count {
+				t.Errorf("got %d values, want %d", len(seen), tt.count)
+			}
+		})
+	}
+}
+
+// TestRandomBatchErrors tests argument validation and the keyspace check.
+func TestRandomBatchErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int
+		length int
+		opts   luhn.BatchOptions
+		want   error
+	}{
+		{"keyspace", 10, 2, luhn.BatchOptions{}, luhn.ErrKeyspace},
+		{"keyspace with prefix", 11, 6, luhn.BatchOptions{Prefix: "4111"}, luhn.ErrKeyspace},
+		{"negative count", -1, 16, luhn.BatchOptions{}, luhn.ErrInvalidCount},
+		{"length too small", 1, 1, luhn.BatchOptions{}, luhn.ErrRandomMin},
+		{"prefix too long", 1, 4, luhn.BatchOptions{Prefix: "4111"}, luhn.ErrPrefixTooLong},
+		{"non-numeric prefix", 1, 16, luhn.BatchOptions{Prefix: "4x"}, luhn.ErrNotNumeric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := luhn.RandomBatch(context.Background(), tt.count, tt.length, tt.opts, func(string) error {
+				calls++
+				return nil
+			})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+			if calls != 0 {
+				t.Errorf("yield called %d times, want 0", calls)
+			}
+		})
+	}
+}
+
+// TestRandomBatchStop verifies that yield errors and context cancellation stop generation.
+func TestRandomBatchStop(t *testing.T) {
+	errStop := errors.New("stop")
+	calls := 0
+	err := luhn.RandomBatch(context.Background(), 100, 16, luhn.BatchOptions{}, func(string) error {
+		calls++
+		if calls == 5 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || calls != 5 {
+		t.Errorf("got %v after %d calls, want %v after 5", err, calls, errStop)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = luhn.RandomBatch(ctx, 100, 16, luhn.BatchOptions{}, func(string) error {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 3 {
+		t.Errorf("got %v after %d calls, want %v after 3", err, calls, context.Canceled)
+	}
+}
+
+// TestRandomBatchSource verifies that a batch reads only the source bytes it
+// uses, leaving the rest for later draws from the same Generator.
+func TestRandomBatchSource(t *testing.T) {
+	g := luhn.NewGenerator(bytes.NewReader(make([]byte, 4096)))
+	err := luhn.RandomBatch(context.Background(), 1, 16, luhn.BatchOptions{Generator: g}, func(string) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RandomBatch error: %v", err)
+	}
+	if _, err := g.Random(16); err != nil {
+		t.Errorf("Random after RandomBatch: %v", err)
+	}
+
+	// A seeded Generator continues the same sequence after a batch.
+	a, b := luhn.NewSeededGenerator(5), luhn.NewSeededGenerator(5)
+	for _, g := range []*luhn.Generator{a, b} {
+		if err := luhn.RandomBatch(context.Background(), 3, 16, luhn.BatchOptions{Generator: g}, func(string) error {
+			return nil
+		}); err != nil {
+			t.Fatalf("RandomBatch error: %v", err)
+		}
+	}
+	x, _ := a.Random(16)
+	y, _ := b.Random(16)
+	if x != y {
+		t.Errorf("Random after RandomBatch = %q and %q, want equal", x, y)
+	}
+}
+
+// TestRandomBatchChan verifies channel delivery and cancellation.
+func TestRandomBatchChan(t *testing.T) {
+	out, errc := luhn.RandomBatchChan(context.Background(), 50, 10, luhn.BatchOptions{})
+	seen := make(map[string]bool)
+	for s := range out {
+		seen[s] = true
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 50 {
+		t.Errorf("got %d distinct values, want 50", len(seen))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errc = luhn.RandomBatchChan(ctx, 1000, 10, luhn.BatchOptions{})
+	<-out
+	cancel()
+	for range out {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+
+	_, errc = luhn.RandomBatchChan(context.Background(), 10, 2, luhn.BatchOptions{})
+	if err := <-errc; !errors.Is(err, luhn.ErrKeyspace) {
+		t.Errorf("got %v, want %v", err, luhn.ErrKeyspace)
+	}
+}
+
+func BenchmarkRandom(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.Random("16")
+	}
+}
+
+func BenchmarkRandomBatch(b *testing.B) {
+	b.ReportAllocs()
+	err := luhn.RandomBatch(context.Background(), b.N, 16, luhn.BatchOptions{}, func(string) error { return nil })
+	if err != nil {
+		b.Fatal(err)
+	}
+}
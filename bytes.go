@@ -0,0 +1,43 @@
+package luhn
+
+import "crypto/subtle"
+
+// AppendCheckDigit appends the Luhn check digit of value to dst and returns
+// the extended buffer, in the manner of strconv.AppendInt. To append the
+// check digit to value itself, pass it as both arguments:
+//
+//	b, err = luhn.AppendCheckDigit(b, b)
+//
+// Returns an error if value fails input validation. It does not allocate
+// unless dst needs to grow.
+func AppendCheckDigit(dst, value []byte) ([]byte, error) {
+	if err := validateInput("AppendCheckDigit", value); err != nil {
+		return dst, err
+	}
+	return append(dst, generateChecksum(value)), nil
+}
+
+// ValidateBytes is like Validate but operates on a byte slice without allocating.
+func ValidateBytes(value []byte) (bool, error) {
+	if err := validateInput("ValidateBytes", value); err != nil {
+		return false, err
+	}
+	if len(value) == 1 {
+		return false, newValidationError("ValidateBytes", ReasonTooShort, ErrMinLength, value, -1)
+	}
+
+	last := len(value) - 1
+	check := generateChecksum(value[:last])
+	// Use constant-time comparison to prevent timing side-channel attacks
+	// that could reveal information about valid check digits.
+	return subtle.ConstantTimeByteEq(check, value[last]) == 1, nil
+}
+
+// ChecksumBytes returns the Luhn check digit of value as an integer in [0, 9]
+// without allocating. Returns an error if value fails input validation.
+func ChecksumBytes(value []byte) (int, error) {
+	if err := validateInput("ChecksumBytes", value); err != nil {
+		return 0, err
+	}
+	return int(generateChecksum(value) - '0'), nil
+}
@@ -0,0 +1,184 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestAppendCheckDigit verifies AppendCheckDigit against SPEC.md §5 test vectors.
+func TestAppendCheckDigit(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1", "18"},
+		{"123", "1230"},
+		{"00123", "001230"},
+		{"7992739871", "79927398713"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			b := []byte(tt.input)
+			got, err := luhn.AppendCheckDigit(b, b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("AppendCheckDigit(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			digit, err := luhn.AppendCheckDigit(nil, []byte(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(digit) != tt.want[len(tt.want)-1:] {
+				t.Errorf("AppendCheckDigit(nil, %q) = %q, want %q", tt.input, digit, tt.want[len(tt.want)-1:])
+			}
+		})
+	}
+}
+
+// TestValidateBytes verifies that ValidateBytes agrees with Validate.
+func TestValidateBytes(t *testing.T) {
+	inputs := []string{"18", "125", "1230", "001230", "10", "120", "1231", "79927398713"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			want, err := luhn.Validate(input)
+			if err != nil {
+				t.Fatalf("Validate error: %v", err)
+			}
+			got, err := luhn.ValidateBytes([]byte(input))
+			if err != nil {
+				t.Fatalf("ValidateBytes error: %v", err)
+			}
+			if got != want {
+				t.Errorf("ValidateBytes(%q) = %v, want %v", input, got, want)
+			}
+		})
+	}
+}
+
+// TestChecksumBytes verifies ChecksumBytes against SPEC.md §5 test vectors.
+func TestChecksumBytes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"1", 8},
+		{"12", 5},
+		{"123", 0},
+		{"7992739871", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := luhn.ChecksumBytes([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ChecksumBytes(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBytesErrors verifies that the byte-slice functions share the string validation rules.
+func TestBytesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"empty", "", luhn.ErrEmpty},
+		{"spaces", " 123 ", luhn.ErrSpaces},
+		{"negative", "-123", luhn.ErrNegative},
+		{"float", "123.45", luhn.ErrFloat},
+		{"non-numeric", "1a", luhn.ErrNotNumeric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := luhn.AppendCheckDigit(nil, []byte(tt.input)); !errors.Is(err, tt.want) {
+				t.Errorf("AppendCheckDigit: got %v, want %v", err, tt.want)
+			}
+			if _, err := luhn.ValidateBytes([]byte(tt.input)); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateBytes: got %v, want %v", err, tt.want)
+			}
+			if _, err := luhn.ChecksumBytes([]byte(tt.input)); !errors.Is(err, tt.want) {
+				t.Errorf("ChecksumBytes: got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := luhn.ValidateBytes([]byte("1")); !errors.Is(err, luhn.ErrMinLength) {
+		t.Errorf("ValidateBytes(\"1\"): got %v, want %v", err, luhn.ErrMinLength)
+	}
+}
+
+// TestBytesAllocs verifies that the byte-slice functions do not allocate.
+func TestBytesAllocs(t *testing.T) {
+	value := []byte("7992739871")
+	full := []byte("79927398713")
+	dst := make([]byte, 0, 16)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"AppendCheckDigit", func() { _, _ = luhn.AppendCheckDigit(dst[:0], value) }},
+		{"ValidateBytes", func() { _, _ = luhn.ValidateBytes(full) }},
+		{"ChecksumBytes", func() { _, _ = luhn.ChecksumBytes(value) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.fn); allocs != 0 {
+				t.Errorf("%s allocated %.0f times, want 0", tt.name, allocs)
+			}
+		})
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.Generate("411111111111111", false)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.Validate("4111111111111111")
+	}
+}
+
+func BenchmarkAppendCheckDigit(b *testing.B) {
+	value := []byte("411111111111111")
+	dst := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.AppendCheckDigit(dst[:0], value)
+	}
+}
+
+func BenchmarkValidateBytes(b *testing.B) {
+	value := []byte("4111111111111111")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.ValidateBytes(value)
+	}
+}
+
+func BenchmarkChecksumBytes(b *testing.B) {
+	value := []byte("411111111111111")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.ChecksumBytes(value)
+	}
+}
@@ -0,0 +1,351 @@
+package card
+
+import (
+	"container/heap"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// binKeyLength is the number of leading PAN digits used for BIN lookups.
+// 6-digit ranges are widened to 8 digits.
+const binKeyLength = 8
+
+// BINRange describes the cards issued under an inclusive range of BINs (bank
+// identification numbers, i.e. IINs).
+type BINRange struct {
+	// Start and End are the first and last BIN of the range. They must have
+	// the same length, either 6 or 8 digits.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Brand is the card network.
+	Brand Brand `json:"brand"`
+	// Issuer is the name of the issuing institution.
+	Issuer string `json:"issuer,omitempty"`
+	// Country is the issuer's country, typically an ISO 3166-1 alpha-2 code.
+	Country string `json:"country,omitempty"`
+	// Type is the card type, e.g. "credit", "debit" or "prepaid".
+	Type string `json:"type,omitempty"`
+	// Lengths lists the allowed PAN lengths. If empty, the brand's lengths
+	// apply.
+	Lengths []int `json:"lengths,omitempty"`
+}
+
+// BINTable maps BINs to the ranges that contain them. Ranges may overlap;
+// a lookup returns the most specific range that matches:
+//
+//  1. an 8-digit range takes precedence over a 6-digit range;
+//  2. among ranges of the same length, the narrower range wins;
+//  3. among equally wide ranges, the one listed later wins, so updates can be
+//     appended to an existing table.
+//
+// The table is flattened into disjoint segments when it is built, so Lookup
+// is a binary search that does not allocate. A BINTable is safe for
+// concurrent use.
+type BINTable struct {
+	ranges []BINRange
+	// starts[i] is the first 8-digit key of segment i, which extends to
+	// starts[i+1]-1; winners[i] indexes ranges, or is -1 for no match.
+	starts  []uint32
+	winners []int32
+}
+
+// binEntry is a range widened to 8-digit keys.
+type binEntry struct {
+	lo, hi uint32
+	digits int
+	index  int
+}
+
+// outranks reports whether e takes precedence over o.
+func (e binEntry) outranks(o binEntry) bool {
+	if e.digits != o.digits {
+		return e.digits > o.digits
+	}
+	if e.hi-e.lo != o.hi-o.lo {
+		return e.hi-e.lo < o.hi-o.lo
+	}
+	return e.index > o.index
+}
+
+// binHeap is a max-heap of the ranges covering the current sweep position.
+type binHeap []binEntry
+
+func (h binHeap) Len() int           { return len(h) }
+func (h binHeap) Less(i, j int) bool { return h[i].outranks(h[j]) }
+func (h binHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *binHeap) Push(x any)        { *h = append(*h, x.(binEntry)) }
+func (h *binHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// NewBINTable builds a table from ranges, which are copied. Returns
+// ErrInvalidBINRange if a range has malformed bounds or lengths.
+func NewBINTable(ranges []BINRange) (*BINTable, error) {
+	t := &BINTable{ranges: make([]BINRange, len(ranges))}
+	entries := make([]binEntry, len(ranges))
+	for i, r := range ranges {
+		e, err := widenRange(r)
+		if err != nil {
+			return nil, err
+		}
+		e.index = i
+		entries[i] = e
+		r.Lengths = append([]int(nil), r.Lengths...)
+		t.ranges[i] = r
+	}
+
+	// Sweep the boundaries in order, keeping the covering ranges in a heap
+	// ordered by precedence. Expired ranges are dropped lazily once they
+	// reach the top.
+	points := make([]uint32, 0, 2*len(entries))
+	for _, e := range entries {
+		points = append(points, e.lo, e.hi+1)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lo < entries[j].lo })
+
+	var h binHeap
+	next := 0
+	for i, x := range points {
+		if i > 0 && x == points[i-1] {
+			continue
+		}
+		for next < len(entries) && entries[next].lo <= x {
+			heap.Push(&h, entries[next])
+			next++
+		}
+		for h.Len() > 0 && h[0].hi < x {
+			heap.Pop(&h)
+		}
+		winner := int32(-1)
+		if h.Len() > 0 {
+			winner = int32(h[0].index)
+		}
+		if n := len(t.winners); n > 0 && t.winners[n-1] == winner {
+			continue
+		}
+		t.starts = append(t.starts, x)
+		t.winners = append(t.winners, winner)
+	}
+	return t, nil
+}
+
+// widenRange validates r and converts its bounds to 8-digit keys.
+func widenRange(r BINRange) (binEntry, error) {
+	digits := len(r.Start)
+	if (digits != 6 && digits != binKeyLength) || len(r.End) != digits {
+		return binEntry{}, luhn.NewValidationError("NewBINTable", ReasonBINRange, ErrInvalidBINRange, "", -1)
+	}
+	lo, okLo := parseKey(r.Start)
+	hi, okHi := parseKey(r.End)
+	if !okLo || !okHi || lo > hi {
+		return binEntry{}, luhn.NewValidationError("NewBINTable", ReasonBINRange, ErrInvalidBINRange, "", -1)
+	}
+	for _, n := range r.Lengths {
+		if n < 12 || n > 19 {
+			return binEntry{}, luhn.NewValidationError("NewBINTable", ReasonBINRange, ErrInvalidBINRange, "", -1)
+		}
+	}
+	if digits == 6 {
+		lo, hi = lo*100, hi*100+99
+	}
+	return binEntry{lo: lo, hi: hi, digits: digits}, nil
+}
+
+// parseKey parses a string of decimal digits.
+func parseKey(s string) (uint32, bool) {
+	var v uint32
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		v = v*10 + uint32(c-'0')
+	}
+	return v, true
+}
+
+// Len returns the number of ranges in the table.
+func (t *BINTable) Len() int {
+	return len(t.ranges)
+}
+
+// Lookup returns the range that takes precedence for the leading digits of
+// pan. pan normally has at least 8 digits, of which the first 8 are used. A
+// 6-digit BIN (or a 7-digit prefix, of which the first 6 are used) matches
+// only if a single range takes precedence for every 8-digit BIN that starts
+// with it; if an 8-digit range covers part of it, Lookup reports no match.
+// Lookup does not validate the rest of pan; use LookupPAN for that, or call
+// luhn.Validate first.
+func (t *BINTable) Lookup(pan string) (BINRange, bool) {
+	if len(pan) < 6 {
+		return BINRange{}, false
+	}
+	if len(pan) < binKeyLength {
+		key, ok := parseKey(pan[:6])
+		if !ok {
+			return BINRange{}, false
+		}
+		i := t.segment(key * 100)
+		if i < 0 || t.winners[i] < 0 || (i+1 < len(t.starts) && t.starts[i+1] <= key*100+99) {
+			return BINRange{}, false
+		}
+		return t.ranges[t.winners[i]], true
+	}
+	key, ok := parseKey(pan[:binKeyLength])
+	if !ok {
+		return BINRange{}, false
+	}
+	i := t.segment(key)
+	if i < 0 || t.winners[i] < 0 {
+		return BINRange{}, false
+	}
+	return t.ranges[t.winners[i]], true
+}
+
+// segment returns the index of the segment containing the 8-digit key, or -1
+// if key precedes every segment.
+func (t *BINTable) segment(key uint32) int {
+	return sort.Search(len(t.starts), func(i int) bool { return t.starts[i] > key }) - 1
+}
+
+// LookupPAN validates pan with luhn.Validate and returns the range that
+// takes precedence for it. Returns ErrInvalidCheckDigit if the check digit is
+// wrong, ErrUnknownBIN if no range matches, and ErrInvalidLength if the
+// length of pan is not allowed by the range (or, if the range lists no
+// lengths, by its brand).
+func (t *BINTable) LookupPAN(pan string) (BINRange, error) {
+	valid, err := luhn.Validate(pan)
+	if err != nil {
+		return BINRange{}, err.(*luhn.ValidationError).WithFunc("BINTable.LookupPAN")
+	}
+	if !valid {
+		return BINRange{}, luhn.NewValidationError("BINTable.LookupPAN", luhn.ReasonCheckDigit, ErrInvalidCheckDigit, "", -1)
+	}
+	r, ok := t.Lookup(pan)
+	if !ok {
+		return BINRange{}, luhn.NewValidationError("BINTable.LookupPAN", ReasonUnknownBIN, ErrUnknownBIN, "", -1)
+	}
+	lengths := r.Lengths
+	if len(lengths) == 0 {
+		lengths = r.Brand.Lengths()
+	}
+	for _, n := range lengths {
+		if n == len(pan) {
+			return r, nil
+		}
+	}
+	return BINRange{}, luhn.NewValidationError("BINTable.LookupPAN", luhn.ReasonLength, ErrInvalidLength, "", -1)
+}
+
+// LoadBINTableJSON reads a table from a JSON array of BINRange objects, e.g.
+//
+//	[{"start": "411111", "end": "411199", "brand": "Visa", "lengths": [16]}]
+func LoadBINTableJSON(r io.Reader) (*BINTable, error) {
+	var ranges []BINRange
+	if err := json.NewDecoder(r).Decode(&ranges); err != nil {
+		return nil, err
+	}
+	return NewBINTable(ranges)
+}
+
+// LoadBINTableCSV reads a table from CSV with a header row. The columns start
+// and end are required; brand, issuer, country, type and lengths are
+// optional and may appear in any order. Lengths are separated by semicolons,
+// and a dash denotes a span, e.g. "16;18-19". Errors in a row are reported
+// with its line number.
+func LoadBINTableCSV(r io.Reader) (*BINTable, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["start"]; !ok {
+		return nil, fmt.Errorf("card: BIN table CSV has no start column")
+	}
+	if _, ok := cols["end"]; !ok {
+		return nil, fmt.Errorf("card: BIN table CSV has no end column")
+	}
+
+	var ranges []BINRange
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := cols[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		br := BINRange{
+			Start:   field("start"),
+			End:     field("end"),
+			Issuer:  field("issuer"),
+			Country: field("country"),
+			Type:    field("type"),
+		}
+		if name := field("brand"); name != "" {
+			if err := br.Brand.UnmarshalText([]byte(name)); err != nil {
+				return nil, fmt.Errorf("card: BIN table line %d: %w", line, err)
+			}
+		}
+		if br.Lengths, err = parseLengths(field("lengths")); err != nil {
+			return nil, fmt.Errorf("card: BIN table line %d: %w", line, err)
+		}
+		if _, err := widenRange(br); err != nil {
+			return nil, fmt.Errorf("card: BIN table line %d: %w", line, err)
+		}
+		ranges = append(ranges, br)
+	}
+	return NewBINTable(ranges)
+}
+
+// parseLengths parses a lengths field such as "16;18-19".
+func parseLengths(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var lengths []int
+	for _, part := range strings.Split(s, ";") {
+		lo, hi, isSpan := strings.Cut(strings.TrimSpace(part), "-")
+		from, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, luhn.NewValidationError("LoadBINTableCSV", ReasonBINRange, ErrInvalidBINRange, "", -1)
+		}
+		to := from
+		if isSpan {
+			if to, err = strconv.Atoi(hi); err != nil {
+				return nil, luhn.NewValidationError("LoadBINTableCSV", ReasonBINRange, ErrInvalidBINRange, "", -1)
+			}
+		}
+		if from < 12 || to > 19 || from > to {
+			return nil, luhn.NewValidationError("LoadBINTableCSV", ReasonBINRange, ErrInvalidBINRange, "", -1)
+		}
+		for n := from; n <= to; n++ {
+			lengths = append(lengths, n)
+		}
+	}
+	return lengths, nil
+}
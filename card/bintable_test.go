@@ -0,0 +1,285 @@
+package card_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+	"github.com/jrrembert/go-luhn/card"
+)
+
+const testBINsCSV = `start,end,brand,issuer,country,type,lengths
+400000,499999,Visa,Any Visa Issuer,,credit,
+411111,411111,Visa,Test Bank,US,credit,16
+41111100,41111109,visa,Eight Digit Bank,GB,debit,16;19
+411111,411111,Visa,Test Bank Reissue,US,prepaid,16
+510000,559999,Mastercard,Any MC Issuer,,credit,
+378282,378282,amex,Amex Test,US,charge,15
+`
+
+// TestLoadBINTableCSV tests loading and the precedence of overlapping ranges.
+func TestLoadBINTableCSV(t *testing.T) {
+	table, err := card.LoadBINTableCSV(strings.NewReader(testBINsCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if table.Len() != 6 {
+		t.Errorf("Len = %d, want 6", table.Len())
+	}
+
+	tests := []struct {
+		pan        string
+		wantIssuer string
+		wantOK     bool
+	}{
+		// The 8-digit range beats the 6-digit ones.
+		{"4111110500000000", "Eight Digit Bank", true},
+		// Of two identical 6-digit ranges, the later one wins.
+		{"4111111111111111", "Test Bank Reissue", true},
+		// The narrower range beats the wider one.
+		{"4111121111111111", "Any Visa Issuer", true},
+		{"4999999999999999", "Any Visa Issuer", true},
+		{"5500000000000004", "Any MC Issuer", true},
+		{"378282246310005", "Amex Test", true},
+		{"3000000000000004", "", false},
+		// A 6-digit BIN matches if one range wins for all of it.
+		{"411112", "Any Visa Issuer", true},
+		{"4111121", "Any Visa Issuer", true},
+		{"378282", "Amex Test", true},
+		// The 8-digit range covers only part of 411111.
+		{"411111", "", false},
+		{"300000", "", false},
+		{"41111", "", false},
+		{"4111", "", false},
+		{"4111a11111111111", "", false},
+	}
+	for _, tt := range tests {
+		r, ok := table.Lookup(tt.pan)
+		if ok != tt.wantOK || r.Issuer != tt.wantIssuer {
+			t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.pan, r.Issuer, ok, tt.wantIssuer, tt.wantOK)
+		}
+	}
+
+	r, _ := table.Lookup("4111110500000000")
+	if r.Brand != card.Visa || r.Country != "GB" || r.Type != "debit" || len(r.Lengths) != 2 || r.Lengths[1] != 19 {
+		t.Errorf("Lookup = %+v", r)
+	}
+}
+
+// TestLoadBINTableJSON tests loading from JSON.
+func TestLoadBINTableJSON(t *testing.T) {
+	const src = `[
+		{"start": "22000000", "end": "22049999", "brand": "Mir", "country": "RU", "lengths": [16]},
+		{"start": "222100", "end": "272099", "brand": "Mastercard"}
+	]`
+	table, err := card.LoadBINTableJSON(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r, ok := table.Lookup("2200000000000004"); !ok || r.Brand != card.Mir || r.Country != "RU" {
+		t.Errorf("Lookup = %+v, %v", r, ok)
+	}
+	if r, ok := table.Lookup("2223000048400011"); !ok || r.Brand != card.Mastercard {
+		t.Errorf("Lookup = %+v, %v", r, ok)
+	}
+	if _, ok := table.Lookup("2205000000000000"); ok {
+		t.Error("Lookup matched a gap between ranges")
+	}
+}
+
+// TestBINTable_LookupBIN tests lookups of a 6-digit BIN within wider and
+// narrower ranges.
+func TestBINTable_LookupBIN(t *testing.T) {
+	table, err := card.NewBINTable([]card.BINRange{
+		{Start: "411100", End: "411199", Brand: card.Visa, Issuer: "Six Digit Bank"},
+		{Start: "41120000", End: "41120099", Brand: card.Visa, Issuer: "Eight Digit Bank"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		bin        string
+		wantIssuer string
+		wantOK     bool
+	}{
+		{"411111", "Six Digit Bank", true},
+		// An 8-digit range covering the whole BIN wins.
+		{"411200", "Eight Digit Bank", true},
+		{"411201", "", false},
+	}
+	for _, tt := range tests {
+		r, ok := table.Lookup(tt.bin)
+		if ok != tt.wantOK || r.Issuer != tt.wantIssuer {
+			t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.bin, r.Issuer, ok, tt.wantIssuer, tt.wantOK)
+		}
+	}
+}
+
+// TestBINTable_LookupPAN tests lookups of validated numbers.
+func TestBINTable_LookupPAN(t *testing.T) {
+	table, err := card.LoadBINTableCSV(strings.NewReader(testBINsCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r, err := table.LookupPAN("4111111111111111"); err != nil || r.Issuer != "Test Bank Reissue" {
+		t.Errorf("LookupPAN = %+v, %v", r, err)
+	}
+	// The range lists no lengths, so the brand's lengths apply.
+	if _, err := table.LookupPAN("4222222222222"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// 19 digits, but the matching range allows only 16.
+	long, err := luhn.Generate("411111111111111111", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		pan     string
+		wantErr error
+	}{
+		{"not numeric", "4111x", luhn.ErrNotNumeric},
+		{"check digit", "4111111111111112", card.ErrInvalidCheckDigit},
+		{"unknown", "3056930009020004", card.ErrUnknownBIN},
+		{"range length", long, card.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := table.LookupPAN(tt.pan)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LookupPAN(%q) = %v, want %v", tt.pan, err, tt.wantErr)
+			}
+			if ve.Func != "BINTable.LookupPAN" {
+				t.Errorf("Func = %q, want BINTable.LookupPAN", ve.Func)
+			}
+		})
+	}
+}
+
+// TestNewBINTable_Errors tests that malformed ranges are rejected.
+func TestNewBINTable_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		r    card.BINRange
+	}{
+		{"short", card.BINRange{Start: "4111", End: "4111"}},
+		{"mismatched", card.BINRange{Start: "411111", End: "41111199"}},
+		{"not numeric", card.BINRange{Start: "41111a", End: "411111"}},
+		{"reversed", card.BINRange{Start: "411112", End: "411111"}},
+		{"bad length", card.BINRange{Start: "411111", End: "411111", Lengths: []int{20}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := card.NewBINTable([]card.BINRange{tt.r})
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, card.ErrInvalidBINRange) {
+				t.Fatalf("got %v, want ErrInvalidBINRange", err)
+			}
+			if ve.Reason != card.ReasonBINRange {
+				t.Errorf("Reason = %q", ve.Reason)
+			}
+		})
+	}
+}
+
+// TestLoadBINTableCSV_Errors tests that CSV errors report the line.
+func TestLoadBINTableCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr error
+		wantMsg string
+	}{
+		{"unknown brand", "start,end,brand\n411111,411111,Visa\n511111,511111,Nope\n", card.ErrUnknownBrand, "line 3"},
+		{"bad range", "start,end\n411111,4111\n", card.ErrInvalidBINRange, "line 2"},
+		{"bad lengths", "start,end,lengths\n411111,411111,16-99\n", card.ErrInvalidBINRange, "line 2"},
+		{"no end column", "start,brand\n411111,Visa\n", nil, "no end column"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := card.LoadBINTableCSV(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %q does not mention %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestBINTable_Lengths tests parsing of spans in the lengths column.
+func TestBINTable_Lengths(t *testing.T) {
+	table, err := card.LoadBINTableCSV(strings.NewReader("start,end,lengths\n411111,411111,13; 16-19\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, _ := table.Lookup("41111111")
+	want := []int{13, 16, 17, 18, 19}
+	if len(r.Lengths) != len(want) {
+		t.Fatalf("Lengths = %v, want %v", r.Lengths, want)
+	}
+	for i := range want {
+		if r.Lengths[i] != want[i] {
+			t.Fatalf("Lengths = %v, want %v", r.Lengths, want)
+		}
+	}
+}
+
+// TestBINTable_LookupAllocs verifies that Lookup does not allocate.
+func TestBINTable_LookupAllocs(t *testing.T) {
+	table, err := card.LoadBINTableCSV(strings.NewReader(testBINsCSV))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = table.Lookup("4111111111111111")
+	})
+	if allocs != 0 {
+		t.Errorf("Lookup allocated %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkBINTable_Lookup(b *testing.B) {
+	// A table of 100,000 overlapping 6- and 8-digit ranges.
+	ranges := make([]card.BINRange, 0, 100000)
+	for i := 0; i < 50000; i++ {
+		bin := 400000 + i*2
+		ranges = append(ranges,
+			card.BINRange{Start: itoa(bin), End: itoa(bin + 1), Brand: card.Visa},
+			card.BINRange{Start: itoa(bin*100 + 50), End: itoa(bin*100 + 59), Brand: card.Visa},
+		)
+	}
+	table, err := card.NewBINTable(ranges)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = table.Lookup("4567895512345678")
+	}
+}
+
+// itoa formats a non-negative integer.
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	var buf [20]byte
+	i := len(buf)
+	for ; n > 0; n /= 10 {
+		i--
+		buf[i] = byte('0' + n%10)
+	}
+	return string(buf[i:])
+}
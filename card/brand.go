@@ -0,0 +1,171 @@
+package card
+
+import (
+	"strings"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// Brand is a payment card network.
+type Brand int
+
+// Supported brands.
+const (
+	Unknown Brand = iota
+	Visa
+	Mastercard
+	Amex
+	Discover
+	JCB
+	UnionPay
+	Maestro
+	DinersClub
+	Mir
+	RuPay
+)
+
+var brandNames = [...]string{
+	Unknown:    "Unknown",
+	Visa:       "Visa",
+	Mastercard: "Mastercard",
+	Amex:       "American Express",
+	Discover:   "Discover",
+	JCB:        "JCB",
+	UnionPay:   "UnionPay",
+	Maestro:    "Maestro",
+	DinersClub: "Diners Club",
+	Mir:        "Mir",
+	RuPay:      "RuPay",
+}
+
+// String returns the name of the brand, e.g. "American Express".
+func (b Brand) String() string {
+	if b < 0 || int(b) >= len(brandNames) {
+		return brandNames[Unknown]
+	}
+	return brandNames[b]
+}
+
+// iinRange is an inclusive range of IIN prefixes of equal length.
+type iinRange struct {
+	low, high string
+}
+
+// brandInfo holds the IIN ranges and allowed PAN lengths of a brand.
+type brandInfo struct {
+	ranges  []iinRange
+	lengths []int
+}
+
+// brands lists the IIN ranges and lengths of each brand. Where ranges of
+// different brands overlap, the longest matching prefix wins, e.g. 6011 is
+// Discover although 60 is RuPay.
+var brands = [...]brandInfo{
+	Visa: {
+		ranges:  []iinRange{{"4", "4"}},
+		lengths: []int{13, 16, 19},
+	},
+	Mastercard: {
+		ranges:  []iinRange{{"51", "55"}, {"2221", "2720"}},
+		lengths: []int{16},
+	},
+	Amex: {
+		ranges:  []iinRange{{"34", "34"}, {"37", "37"}},
+		lengths: []int{15},
+	},
+	Discover: {
+		ranges:  []iinRange{{"6011", "6011"}, {"644", "649"}, {"65", "65"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	JCB: {
+		ranges:  []iinRange{{"3528", "3589"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	UnionPay: {
+		ranges:  []iinRange{{"62", "62"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	Maestro: {
+		ranges: []iinRange{
+			{"5018", "5018"}, {"5020", "5020"}, {"5038", "5038"}, {"5893", "5893"},
+			{"6304", "6304"}, {"6759", "6759"}, {"6761", "6763"},
+		},
+		lengths: []int{12, 13, 14, 15, 16, 17, 18, 19},
+	},
+	DinersClub: {
+		ranges:  []iinRange{{"300", "305"}, {"36", "36"}, {"38", "39"}},
+		lengths: []int{14, 15, 16, 17, 18, 19},
+	},
+	Mir: {
+		ranges:  []iinRange{{"2200", "2204"}},
+		lengths: []int{16, 17, 18, 19},
+	},
+	RuPay: {
+		ranges:  []iinRange{{"60", "60"}, {"508", "508"}, {"81", "82"}},
+		lengths: []int{16},
+	},
+}
+
+// Lengths returns the PAN lengths allowed for the brand, in ascending order.
+// It returns nil for Unknown.
+func (b Brand) Lengths() []int {
+	if b <= Unknown || int(b) >= len(brands) {
+		return nil
+	}
+	return append([]int(nil), brands[b].lengths...)
+}
+
+// ValidLength reports whether n is an allowed PAN length for the brand.
+func (b Brand) ValidLength(n int) bool {
+	for _, l := range b.Lengths() {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect returns the brand whose IIN ranges match the leading digits of pan,
+// or Unknown. pan may be a partial number, e.g. while it is being typed; a
+// prefix too short to decide between brands returns Unknown.
+func Detect(pan string) Brand {
+	best, bestLen := Unknown, 0
+	for b := Visa; int(b) < len(brands); b++ {
+		for _, r := range brands[b].ranges {
+			n := len(r.low)
+			if n <= bestLen || len(pan) < n {
+				continue
+			}
+			if p := pan[:n]; p >= r.low && p <= r.high {
+				best, bestLen = b, n
+			}
+		}
+	}
+	return best
+}
+
+// brandKeys maps the lowercase names accepted by UnmarshalText to brands.
+var brandKeys = func() map[string]Brand {
+	m := map[string]Brand{"amex": Amex, "diners": DinersClub, "dinersclub": DinersClub}
+	for b := Unknown; int(b) < len(brandNames); b++ {
+		m[strings.ToLower(brandNames[b])] = b
+	}
+	return m
+}()
+
+// MarshalText implements encoding.TextMarshaler, returning the brand name.
+func (b Brand) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the names
+// returned by String in any case, as well as "amex" and "diners". Returns
+// ErrUnknownBrand for other names.
+func (b *Brand) UnmarshalText(text []byte) error {
+	brand, ok := brandKeys[strings.ToLower(string(text))]
+	if !ok {
+		return luhn.NewValidationError("Brand.UnmarshalText", ReasonUnknownBrand, ErrUnknownBrand, "", -1)
+	}
+	*b = brand
+	return nil
+}
@@ -0,0 +1,111 @@
+package card_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jrrembert/go-luhn/card"
+)
+
+// TestDetect tests detection from partial numbers and range boundaries.
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   card.Brand
+	}{
+		{"", card.Unknown},
+		{"4", card.Visa},
+		{"34", card.Amex},
+		{"37", card.Amex},
+		{"35", card.Unknown},
+		{"3528", card.JCB},
+		{"3589", card.JCB},
+		{"3590", card.Unknown},
+		{"51", card.Mastercard},
+		{"55", card.Mastercard},
+		{"56", card.Unknown},
+		{"2221", card.Mastercard},
+		{"2720", card.Mastercard},
+		{"2721", card.Unknown},
+		{"2204", card.Mir},
+		{"2205", card.Unknown},
+		{"60", card.RuPay},
+		{"6011", card.Discover},
+		{"644", card.Discover},
+		{"65", card.Discover},
+		{"62", card.UnionPay},
+		{"6304", card.Maestro},
+		{"5018", card.Maestro},
+		{"508", card.RuPay},
+		{"300", card.DinersClub},
+		{"306", card.Unknown},
+		{"36", card.DinersClub},
+		{"9", card.Unknown},
+	}
+
+	for _, tt := range tests {
+		if got := card.Detect(tt.prefix); got != tt.want {
+			t.Errorf("Detect(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+// TestBrand_Lengths tests the allowed lengths of a few brands.
+func TestBrand_Lengths(t *testing.T) {
+	if !card.Amex.ValidLength(15) || card.Amex.ValidLength(16) {
+		t.Error("Amex must allow exactly 15 digits")
+	}
+	if !card.Visa.ValidLength(19) || card.Visa.ValidLength(17) {
+		t.Error("Visa must allow 13, 16 and 19 digits")
+	}
+	if card.Unknown.Lengths() != nil {
+		t.Error("Unknown must have no lengths")
+	}
+
+	// Lengths returns a copy.
+	l := card.Mastercard.Lengths()
+	l[0] = 0
+	if !card.Mastercard.ValidLength(16) {
+		t.Error("Lengths exposed internal state")
+	}
+}
+
+// TestBrand_String tests brand names.
+func TestBrand_String(t *testing.T) {
+	if got := card.Amex.String(); got != "American Express" {
+		t.Errorf("got %q", got)
+	}
+	if got := card.Brand(99).String(); got != "Unknown" {
+		t.Errorf("got %q", got)
+	}
+}
+
+// TestBrand_UnmarshalText tests parsing brand names.
+func TestBrand_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		text string
+		want card.Brand
+	}{
+		{"Visa", card.Visa},
+		{"VISA", card.Visa},
+		{"American Express", card.Amex},
+		{"amex", card.Amex},
+		{"Diners Club", card.DinersClub},
+		{"diners", card.DinersClub},
+		{"unionpay", card.UnionPay},
+	}
+	for _, tt := range tests {
+		var b card.Brand
+		if err := b.UnmarshalText([]byte(tt.text)); err != nil || b != tt.want {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v", tt.text, b, err, tt.want)
+		}
+	}
+
+	var b card.Brand
+	if err := b.UnmarshalText([]byte("Nope")); !errors.Is(err, card.ErrUnknownBrand) {
+		t.Errorf("got %v, want ErrUnknownBrand", err)
+	}
+	if text, _ := card.Amex.MarshalText(); string(text) != "American Express" {
+		t.Errorf("MarshalText = %q", text)
+	}
+}
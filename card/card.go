@@ -0,0 +1,56 @@
+// Package card parses payment card numbers (PANs) and identifies their brand
+// from the IIN ranges and lengths of the major card networks, on top of the
+// Luhn validation of package luhn.
+package card
+
+import (
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// iinLength is the number of leading digits reported as the IIN.
+const iinLength = 6
+
+// Card is a parsed payment card number.
+type Card struct {
+	// PAN is the full primary account number.
+	PAN string
+	// Brand is the card network identified from the IIN.
+	Brand Brand
+	// IIN is the issuer identification number, the first six digits.
+	IIN string
+	// AccountIdentifier is the digits between the IIN and the check digit.
+	AccountIdentifier string
+	// CheckDigit is the Luhn check digit, the last digit of the PAN.
+	CheckDigit int
+}
+
+// ParsePAN validates pan with luhn.Validate and splits it into its parts.
+// Returns ErrInvalidCheckDigit if the check digit is wrong, ErrUnknownBrand
+// if no brand's IIN ranges match, and ErrInvalidLength if the brand does not
+// issue PANs of that length. Input errors are those of luhn.Validate, reported
+// as coming from ParsePAN.
+func ParsePAN(pan string) (Card, error) {
+	valid, err := luhn.Validate(pan)
+	if err != nil {
+		return Card{}, err.(*luhn.ValidationError).WithFunc("ParsePAN")
+	}
+	if !valid {
+		return Card{}, luhn.NewValidationError("ParsePAN", luhn.ReasonCheckDigit, ErrInvalidCheckDigit, "", -1)
+	}
+
+	brand := Detect(pan)
+	if brand == Unknown {
+		return Card{}, luhn.NewValidationError("ParsePAN", ReasonUnknownBrand, ErrUnknownBrand, "", -1)
+	}
+	if !brand.ValidLength(len(pan)) {
+		return Card{}, luhn.NewValidationError("ParsePAN", luhn.ReasonLength, ErrInvalidLength, "", -1)
+	}
+
+	return Card{
+		PAN:               pan,
+		Brand:             brand,
+		IIN:               pan[:iinLength],
+		AccountIdentifier: pan[iinLength : len(pan)-1],
+		CheckDigit:        int(pan[len(pan)-1] - '0'),
+	}, nil
+}
@@ -0,0 +1,107 @@
+package card_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+	"github.com/jrrembert/go-luhn/card"
+)
+
+// withCheck appends the Luhn check digit to payload.
+func withCheck(t *testing.T, payload string) string {
+	t.Helper()
+	pan, err := luhn.Generate(payload, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return pan
+}
+
+// TestParsePAN tests brand detection on well-known test card numbers.
+func TestParsePAN(t *testing.T) {
+	tests := []struct {
+		pan   string
+		brand card.Brand
+	}{
+		{"4111111111111111", card.Visa},
+		{"4222222222222", card.Visa},
+		{"5555555555554444", card.Mastercard},
+		{"2223000048400011", card.Mastercard},
+		{"378282246310005", card.Amex},
+		{"371449635398431", card.Amex},
+		{"6011111111111117", card.Discover},
+		{"6445644564456445", card.Discover},
+		{"3530111333300000", card.JCB},
+		{"6200000000000005", card.UnionPay},
+		{"6759649826438453", card.Maestro},
+		{"36227206271667", card.DinersClub},
+		{"3056930009020004", card.DinersClub},
+		{"2200000000000004", card.Mir},
+		{withCheck(t, "607000000000000"), card.RuPay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pan, func(t *testing.T) {
+			c, err := card.ParsePAN(tt.pan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.Brand != tt.brand {
+				t.Errorf("Brand = %v, want %v", c.Brand, tt.brand)
+			}
+			if c.PAN != tt.pan {
+				t.Errorf("PAN = %q, want %q", c.PAN, tt.pan)
+			}
+		})
+	}
+}
+
+// TestParsePAN_Parts tests the IIN, account identifier and check digit.
+func TestParsePAN_Parts(t *testing.T) {
+	c, err := card.ParsePAN("378282246310005")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := card.Card{
+		PAN:               "378282246310005",
+		Brand:             card.Amex,
+		IIN:               "378282",
+		AccountIdentifier: "24631000",
+		CheckDigit:        5,
+	}
+	if c != want {
+		t.Errorf("ParsePAN = %+v, want %+v", c, want)
+	}
+}
+
+// TestParsePAN_Errors tests the failures of each check.
+func TestParsePAN_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		pan        string
+		wantErr    error
+		wantReason luhn.Reason
+	}{
+		{"not numeric", "4111-1111-1111-1111", luhn.ErrNegative, luhn.ReasonNegative},
+		{"empty", "", luhn.ErrEmpty, luhn.ReasonEmpty},
+		{"check digit", "4111111111111112", card.ErrInvalidCheckDigit, luhn.ReasonCheckDigit},
+		{"unknown brand", withCheck(t, "911111111111111"), card.ErrUnknownBrand, card.ReasonUnknownBrand},
+		{"amex length", withCheck(t, "378282246310"), card.ErrInvalidLength, luhn.ReasonLength},
+		{"visa length", withCheck(t, "41111111111111"), card.ErrInvalidLength, luhn.ReasonLength},
+		{"mastercard 2-series boundary", withCheck(t, "272100000000000"), card.ErrUnknownBrand, card.ReasonUnknownBrand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := card.ParsePAN(tt.pan)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParsePAN(%q) = %v, want %v", tt.pan, err, tt.wantErr)
+			}
+			if ve.Reason != tt.wantReason || ve.Func != "ParsePAN" {
+				t.Errorf("Reason, Func = %q, %q, want %q, ParsePAN", ve.Reason, ve.Func, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package card
+
+import (
+	"errors"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// Card-specific failures, reported in a *luhn.ValidationError like the luhn
+// input errors (luhn.ErrNotNumeric, ...). Use errors.Is to test for a
+// specific failure.
+var (
+	ErrInvalidCheckDigit = errors.New("invalid check digit")
+	ErrUnknownBrand      = errors.New("unknown card brand")
+	ErrInvalidLength     = errors.New("invalid card number length")
+	ErrInvalidBINRange   = errors.New("invalid BIN range")
+	ErrUnknownBIN        = errors.New("no BIN range matches the card number")
+	ErrInvalidMaskPolicy = errors.New("invalid mask policy")
+)
+
+// Reason codes for the card-specific failures. Length and check digit
+// failures use luhn.ReasonLength and luhn.ReasonCheckDigit.
+const (
+	ReasonUnknownBrand luhn.Reason = "unknown_brand"
+	ReasonBINRange     luhn.Reason = "bin_range"
+	ReasonUnknownBIN   luhn.Reason = "unknown_bin"
+	ReasonMaskPolicy   luhn.Reason = "mask_policy"
+)
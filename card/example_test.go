@@ -0,0 +1,34 @@
+package card_test
+
+import (
+	"fmt"
+
+	"github.com/jrrembert/go-luhn/card"
+)
+
+func ExampleParsePAN() {
+	c, err := card.ParsePAN("378282246310005")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(c.Brand, c.IIN, c.AccountIdentifier, c.CheckDigit)
+	// Output: American Express 378282 24631000 5
+}
+
+func ExampleFormat() {
+	s, err := card.Format("378282246310005")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s)
+	// Output: 3782 822463 10005
+}
+
+func ExampleMask() {
+	s, err := card.Mask("4111111111111111", card.MaskFirst6Last4, card.WithSeparator(" "))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s)
+	// Output: 4111 11** **** 1111
+}
@@ -0,0 +1,129 @@
+package card
+
+import (
+	"strings"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// PANs are 12 to 19 digits long (ISO/IEC 7812-1).
+const (
+	minPANLength = 12
+	maxPANLength = 19
+)
+
+// MaskPolicy selects which digits Mask leaves visible.
+type MaskPolicy int
+
+// Masking policies permitted by PCI DSS for displaying a PAN.
+const (
+	// MaskFirst6Last4 shows the first six and last four digits.
+	MaskFirst6Last4 MaskPolicy = iota
+	// MaskBIN8Last4 shows the first eight and last four digits of PANs with
+	// at least 16 digits, and the first six and last four of shorter PANs.
+	MaskBIN8Last4
+	// MaskLast4 shows only the last four digits.
+	MaskLast4
+)
+
+// groupLayouts lists the digit groups of each PAN length. Brand-specific
+// layouts are applied in groupsFor.
+var groupLayouts = map[int][]int{
+	12: {4, 4, 4},
+	13: {4, 4, 5},
+	14: {4, 6, 4},
+	15: {4, 4, 4, 3},
+	16: {4, 4, 4, 4},
+	17: {4, 4, 4, 5},
+	18: {4, 4, 4, 6},
+	19: {4, 4, 4, 4, 3},
+}
+
+// groupsFor returns the digit groups for a PAN of length n and brand b.
+func groupsFor(b Brand, n int) []int {
+	switch {
+	case b == Amex && n == 15:
+		return []int{4, 6, 5}
+	case b == DinersClub && n == 14:
+		return []int{4, 6, 4}
+	}
+	return groupLayouts[n]
+}
+
+// checkPAN validates pan for Format and Mask, reporting errors as coming
+// from fn.
+func checkPAN(fn, pan string, o options) error {
+	valid, err := luhn.Validate(pan)
+	if err != nil {
+		return err.(*luhn.ValidationError).WithFunc(fn)
+	}
+	if len(pan) < minPANLength || len(pan) > maxPANLength {
+		return luhn.NewValidationError(fn, luhn.ReasonLength, ErrInvalidLength, "", -1)
+	}
+	if !valid && !o.allowInvalid {
+		return luhn.NewValidationError(fn, luhn.ReasonCheckDigit, ErrInvalidCheckDigit, "", -1)
+	}
+	return nil
+}
+
+// group joins the digits of s in the layout for the brand of pan.
+func group(pan, s, sep string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 4*len(sep))
+	i := 0
+	for _, g := range groupsFor(Detect(pan), len(s)) {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(s[i : i+g])
+		i += g
+	}
+	return b.String()
+}
+
+// Format groups the digits of pan for display using the layout of its brand,
+// e.g. 4-4-4-4 for most 16-digit cards, 4-6-5 for American Express and 4-6-4
+// for 14-digit Diners Club cards. pan must be 12 to 19 digits long and pass
+// luhn.Validate unless AllowInvalid is given.
+func Format(pan string, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	if err := checkPAN("Format", pan, o); err != nil {
+		return "", err
+	}
+	return group(pan, pan, o.separator), nil
+}
+
+// Mask replaces the digits of pan hidden by policy with '*' (see
+// WithMaskChar). pan must be 12 to 19 digits long and pass luhn.Validate
+// unless AllowInvalid is given. The result is grouped as by Format if
+// WithSeparator is given.
+func Mask(pan string, policy MaskPolicy, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	if err := checkPAN("Mask", pan, o); err != nil {
+		return "", err
+	}
+
+	var first int
+	switch policy {
+	case MaskFirst6Last4:
+		first = 6
+	case MaskBIN8Last4:
+		first = 6
+		if len(pan) >= 16 {
+			first = 8
+		}
+	case MaskLast4:
+		first = 0
+	default:
+		return "", luhn.NewValidationError("Mask", ReasonMaskPolicy, ErrInvalidMaskPolicy, "", -1)
+	}
+
+	masked := []byte(pan)
+	for i := first; i < len(masked)-4; i++ {
+		masked[i] = o.maskChar
+	}
+	if !o.hasSeparator {
+		return string(masked), nil
+	}
+	return group(pan, string(masked), o.separator), nil
+}
@@ -0,0 +1,126 @@
+package card_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+	"github.com/jrrembert/go-luhn/card"
+)
+
+// TestFormat tests the brand-specific digit groupings.
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		pan  string
+		want string
+	}{
+		{"visa 16", "4111111111111111", "4111 1111 1111 1111"},
+		{"visa 13", "4222222222222", "4222 2222 22222"},
+		{"amex", "378282246310005", "3782 822463 10005"},
+		{"diners 14", "36227206271667", "3622 720627 1667"},
+		{"diners 16", "3056930009020004", "3056 9300 0902 0004"},
+		{"unionpay 19", withCheck(t, "620000000000000000"), "6200 0000 0000 0000 00" + withCheck(t, "620000000000000000")[18:]},
+		{"maestro 12", withCheck(t, "67596498264"), "6759 6498 264" + withCheck(t, "67596498264")[11:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := card.Format(tt.pan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format(%q) = %q, want %q", tt.pan, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormat_Options tests custom separators and invalid numbers.
+func TestFormat_Options(t *testing.T) {
+	got, err := card.Format("4111111111111111", card.WithSeparator("-"))
+	if err != nil || got != "4111-1111-1111-1111" {
+		t.Errorf("Format = %q, %v", got, err)
+	}
+
+	if _, err := card.Format("4111111111111112"); !errors.Is(err, card.ErrInvalidCheckDigit) {
+		t.Errorf("got %v, want ErrInvalidCheckDigit", err)
+	}
+	got, err = card.Format("4111111111111112", card.AllowInvalid())
+	if err != nil || got != "4111 1111 1111 1112" {
+		t.Errorf("Format = %q, %v", got, err)
+	}
+}
+
+// TestFormat_Errors tests input errors, which AllowInvalid does not bypass.
+func TestFormat_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pan     string
+		wantErr error
+	}{
+		{"empty", "", luhn.ErrEmpty},
+		{"spaces", "4111 1111 1111 1111", luhn.ErrSpaces},
+		{"not numeric", "4111a11111111111", luhn.ErrNotNumeric},
+		{"too short", "79927398713", card.ErrInvalidLength},
+		{"too long", withCheck(t, "4111111111111111111"), card.ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ve *luhn.ValidationError
+			_, err := card.Format(tt.pan, card.AllowInvalid())
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) || ve.Func != "Format" {
+				t.Errorf("Format(%q) = %v, want %v from Format", tt.pan, err, tt.wantErr)
+			}
+			_, err = card.Mask(tt.pan, card.MaskLast4, card.AllowInvalid())
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) || ve.Func != "Mask" {
+				t.Errorf("Mask(%q) = %v, want %v from Mask", tt.pan, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMask tests each masking policy.
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name   string
+		pan    string
+		policy card.MaskPolicy
+		opts   []card.Option
+		want   string
+	}{
+		{"first6 last4", "4111111111111111", card.MaskFirst6Last4, nil, "411111******1111"},
+		{"bin8 last4", "4111111111111111", card.MaskBIN8Last4, nil, "41111111****1111"},
+		{"bin8 falls back for amex", "378282246310005", card.MaskBIN8Last4, nil, "378282*****0005"},
+		{"last4", "4111111111111111", card.MaskLast4, nil, "************1111"},
+		{"grouped", "378282246310005", card.MaskFirst6Last4, []card.Option{card.WithSeparator(" ")}, "3782 82**** *0005"},
+		{"mask char", "4111111111111111", card.MaskFirst6Last4, []card.Option{card.WithMaskChar('X')}, "411111XXXXXX1111"},
+		{"invalid allowed", "4111111111111112", card.MaskLast4, []card.Option{card.AllowInvalid()}, "************1112"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := card.Mask(tt.pan, tt.policy, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Mask(%q) = %q, want %q", tt.pan, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMask_Errors tests rejected numbers and policies.
+func TestMask_Errors(t *testing.T) {
+	_, err := card.Mask("4111111111111112", card.MaskLast4)
+	var ve *luhn.ValidationError
+	if !errors.As(err, &ve) || !errors.Is(err, card.ErrInvalidCheckDigit) || ve.Func != "Mask" {
+		t.Errorf("got %v, want ErrInvalidCheckDigit from Mask", err)
+	}
+	if _, err := card.Mask("4111111111111111", card.MaskPolicy(99)); !errors.Is(err, card.ErrInvalidMaskPolicy) {
+		t.Errorf("got %v, want ErrInvalidMaskPolicy", err)
+	}
+}
@@ -0,0 +1,46 @@
+package card
+
+// Option configures Format and Mask.
+type Option func(*options)
+
+type options struct {
+	allowInvalid bool
+	separator    string
+	hasSeparator bool
+	maskChar     byte
+}
+
+// newOptions applies opts to the defaults.
+func newOptions(opts []Option) options {
+	o := options{separator: " ", maskChar: '*'}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// AllowInvalid formats or masks numbers whose Luhn check digit is wrong,
+// which are otherwise rejected with ErrInvalidCheckDigit. Input that is not
+// numeric is still rejected.
+func AllowInvalid() Option {
+	return func(o *options) {
+		o.allowInvalid = true
+	}
+}
+
+// WithSeparator sets the string placed between digit groups. Format uses a
+// space by default; Mask groups digits only if this option is given.
+func WithSeparator(sep string) Option {
+	return func(o *options) {
+		o.separator = sep
+		o.hasSeparator = true
+	}
+}
+
+// WithMaskChar sets the character that replaces hidden digits in Mask. The
+// default is '*'.
+func WithMaskChar(c byte) Option {
+	return func(o *options) {
+		o.maskChar = c
+	}
+}
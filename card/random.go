@@ -0,0 +1,108 @@
+package card
+
+import (
+	"strconv"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// defaultGenerator draws from crypto/rand.
+var defaultGenerator = luhn.NewGenerator(nil)
+
+// RandomOptions configures RandomCard.
+type RandomOptions struct {
+	// BIN fixes the leading digits of the number. It must fall within the
+	// brand's IIN ranges. If empty, a prefix is drawn from those ranges.
+	BIN string
+	// Length is the number of digits. It must be allowed for the brand. If
+	// zero, one of the brand's lengths is drawn at random.
+	Length int
+	// Generator supplies the randomness. If nil, crypto/rand is used.
+	Generator *luhn.Generator
+}
+
+// RandomCard generates a random card number of the given brand, for test
+// and sandbox use. The number starts with an IIN from the brand's ranges,
+// has a length the brand issues, ends with a valid Luhn check digit, and is
+// detected as the brand by Detect and ParsePAN.
+//
+// Returns ErrUnknownBrand if brand is not supported, ErrUnknownBIN if
+// opts.BIN is not within the brand's ranges, and ErrInvalidLength if
+// opts.Length is not allowed for the brand or opts.BIN leaves no room for
+// the remaining digits.
+func RandomCard(brand Brand, opts RandomOptions) (string, error) {
+	if brand <= Unknown || int(brand) >= len(brands) {
+		return "", luhn.NewValidationError("RandomCard", ReasonUnknownBrand, ErrUnknownBrand, "", -1)
+	}
+	info := brands[brand]
+	if opts.BIN != "" {
+		// Generate applies the luhn input checks to the BIN.
+		if _, err := luhn.Generate(opts.BIN, true); err != nil {
+			return "", err.(*luhn.ValidationError).WithFunc("RandomCard")
+		}
+		if Detect(opts.BIN) != brand {
+			return "", luhn.NewValidationError("RandomCard", ReasonUnknownBIN, ErrUnknownBIN, "", -1)
+		}
+	}
+	if opts.Length != 0 && (!brand.ValidLength(opts.Length) || len(opts.BIN) >= opts.Length) {
+		return "", luhn.NewValidationError("RandomCard", luhn.ReasonLength, ErrInvalidLength, "", -1)
+	}
+	if maxLength := info.lengths[len(info.lengths)-1]; len(opts.BIN) >= maxLength {
+		return "", luhn.NewValidationError("RandomCard", luhn.ReasonLength, ErrInvalidLength, "", -1)
+	}
+
+	g := opts.Generator
+	if g == nil {
+		g = defaultGenerator
+	}
+
+	// Draw until the number is detected as brand: a prefix from a broad
+	// range (e.g. RuPay's 60) may continue into a more specific range of
+	// another brand (e.g. Discover's 6011).
+	for {
+		length := opts.Length
+		if length == 0 {
+			i, err := g.Intn(len(info.lengths))
+			if err != nil {
+				return "", err
+			}
+			length = info.lengths[i]
+			if len(opts.BIN) >= length {
+				continue
+			}
+		}
+
+		prefix := opts.BIN
+		if prefix == "" {
+			var err error
+			if prefix, err = randomPrefix(g, info.ranges); err != nil {
+				return "", err
+			}
+		}
+
+		pan, err := g.RandomWithPrefix(prefix, length)
+		if err != nil {
+			return "", err
+		}
+		if Detect(pan) == brand {
+			return pan, nil
+		}
+	}
+}
+
+// randomPrefix draws one of ranges uniformly, then a prefix uniformly from
+// within it.
+func randomPrefix(g *luhn.Generator, ranges []iinRange) (string, error) {
+	i, err := g.Intn(len(ranges))
+	if err != nil {
+		return "", err
+	}
+	r := ranges[i]
+	low, _ := strconv.Atoi(r.low)
+	high, _ := strconv.Atoi(r.high)
+	offset, err := g.Intn(high - low + 1)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(low + offset), nil
+}
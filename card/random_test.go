@@ -0,0 +1,114 @@
+package card_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+	"github.com/jrrembert/go-luhn/card"
+)
+
+// TestRandomCard tests that generated numbers parse as the requested brand.
+func TestRandomCard(t *testing.T) {
+	g := luhn.NewSeededGenerator(1)
+	for b := card.Visa; b <= card.RuPay; b++ {
+		t.Run(b.String(), func(t *testing.T) {
+			lengths := map[int]bool{}
+			for i := 0; i < 200; i++ {
+				pan, err := card.RandomCard(b, card.RandomOptions{Generator: g})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				c, err := card.ParsePAN(pan)
+				if err != nil {
+					t.Fatalf("ParsePAN(%q): %v", pan, err)
+				}
+				if c.Brand != b {
+					t.Fatalf("ParsePAN(%q).Brand = %v, want %v", pan, c.Brand, b)
+				}
+				lengths[len(pan)] = true
+			}
+			if len(lengths) != len(b.Lengths()) {
+				t.Errorf("generated lengths %v, want all of %v", lengths, b.Lengths())
+			}
+		})
+	}
+}
+
+// TestRandomCard_Options tests the BIN and Length options.
+func TestRandomCard_Options(t *testing.T) {
+	g := luhn.NewSeededGenerator(2)
+	for i := 0; i < 50; i++ {
+		pan, err := card.RandomCard(card.Visa, card.RandomOptions{BIN: "41111111", Length: 16, Generator: g})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(pan, "41111111") || len(pan) != 16 {
+			t.Fatalf("RandomCard = %q", pan)
+		}
+		if ok, _ := luhn.Validate(pan); !ok {
+			t.Fatalf("RandomCard = %q is not Luhn-valid", pan)
+		}
+	}
+
+	// RuPay's 60 overlaps Discover's 6011, which must never be produced.
+	for i := 0; i < 500; i++ {
+		pan, err := card.RandomCard(card.RuPay, card.RandomOptions{BIN: "60", Generator: g})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.HasPrefix(pan, "6011") {
+			t.Fatalf("RandomCard(RuPay) = %q, a Discover number", pan)
+		}
+	}
+}
+
+// TestRandomCard_Deterministic tests that seeded generators reproduce numbers.
+func TestRandomCard_Deterministic(t *testing.T) {
+	a, _ := card.RandomCard(card.Mastercard, card.RandomOptions{Generator: luhn.NewSeededGenerator(9)})
+	b, _ := card.RandomCard(card.Mastercard, card.RandomOptions{Generator: luhn.NewSeededGenerator(9)})
+	if a != b || a == "" {
+		t.Errorf("got %q and %q", a, b)
+	}
+}
+
+// TestRandomCard_Errors tests rejected brands and options.
+func TestRandomCard_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		brand   card.Brand
+		opts    card.RandomOptions
+		wantErr error
+	}{
+		{"unknown brand", card.Unknown, card.RandomOptions{}, card.ErrUnknownBrand},
+		{"out of range brand", card.Brand(99), card.RandomOptions{}, card.ErrUnknownBrand},
+		{"bin of other brand", card.Visa, card.RandomOptions{BIN: "51"}, card.ErrUnknownBIN},
+		{"more specific bin of other brand", card.RuPay, card.RandomOptions{BIN: "6011"}, card.ErrUnknownBIN},
+		{"bin not numeric", card.Visa, card.RandomOptions{BIN: "4a"}, luhn.ErrNotNumeric},
+		{"length", card.Amex, card.RandomOptions{Length: 16}, card.ErrInvalidLength},
+		{"bin fills length", card.Amex, card.RandomOptions{BIN: "341111111111111", Length: 15}, card.ErrInvalidLength},
+		{"bin fills every length", card.Mastercard, card.RandomOptions{BIN: "5111111111111111"}, card.ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := card.RandomCard(tt.brand, tt.opts)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if ve.Func != "RandomCard" {
+				t.Errorf("Func = %q, want RandomCard", ve.Func)
+			}
+		})
+	}
+}
+
+// TestRandomCard_ReaderError tests that entropy source failures are returned.
+func TestRandomCard_ReaderError(t *testing.T) {
+	g := luhn.NewGenerator(strings.NewReader(""))
+	if _, err := card.RandomCard(card.Visa, card.RandomOptions{Generator: g}); err == nil {
+		t.Error("expected error from exhausted reader, got nil")
+	}
+}
@@ -0,0 +1,92 @@
+package luhn
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CasePolicy controls how the mod-N functions treat letter case in their
+// input and render the check character. It is selected with WithCase.
+type CasePolicy int
+
+const (
+	// CaseDefault is the behaviour required by the spec: letters are accepted
+	// in either case, Generate echoes the input unchanged, and the check
+	// character is rendered in uppercase.
+	CaseDefault CasePolicy = iota
+	// CaseStrictUpper rejects lowercase letters with ErrLowercase.
+	CaseStrictUpper
+	// CaseFold accepts letters in either case and converts the input to
+	// uppercase, so Generate returns an all-uppercase value.
+	CaseFold
+	// CasePreserve accepts letters in either case and echoes the input
+	// unchanged. The check character is rendered in lowercase if the letters
+	// of the payload are all lowercase, and in uppercase otherwise. Validate
+	// accepts a value only if its check character is in that case.
+	CasePreserve
+)
+
+// WithCase selects the case policy of the mod-N functions. It has no effect
+// on the decimal functions.
+func WithCase(policy CasePolicy) Option {
+	return func(o *options) {
+		o.casePolicy = policy
+	}
+}
+
+// prepareModN normalizes value and applies the case policy for the mod-N
+// functions over a. fn names the public function reported in any returned
+// error.
+func (o options) prepareModN(fn, value string, a *Alphabet) (string, error) {
+	value, err := o.normalize(fn, value)
+	if err != nil {
+		return "", err
+	}
+
+	switch o.casePolicy {
+	case CaseStrictUpper:
+		// Report invalid characters before case errors.
+		if _, err := a.validateInput(fn, value); err != nil {
+			return "", err
+		}
+		for i, r := range value {
+			if unicode.IsLower(r) {
+				return "", newValidationError(fn, ReasonCase, ErrLowercase, value, i)
+			}
+		}
+	case CaseFold:
+		value = strings.ToUpper(value)
+	}
+	return value, nil
+}
+
+// lowerCheck reports whether CasePreserve renders the check character of
+// payload in lowercase.
+func lowerCheck(payload string) bool {
+	lower := false
+	for _, r := range payload {
+		if unicode.IsUpper(r) {
+			return false
+		}
+		if unicode.IsLower(r) {
+			lower = true
+		}
+	}
+	return lower
+}
+
+// renderCheck returns check in the case CasePreserve expects for payload.
+func renderCheck(payload string, check rune) rune {
+	if lowerCheck(payload) {
+		return unicode.ToLower(check)
+	}
+	return unicode.ToUpper(check)
+}
+
+// preserveCase rewrites the check character at the end of generated, which
+// was produced from payload, according to CasePreserve.
+func preserveCase(payload, generated string) string {
+	check, size := utf8.DecodeLastRuneInString(generated)
+	return generated[:len(generated)-size] + string(renderCheck(payload, check))
+}
@@ -0,0 +1,138 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestGenerateModN_CasePolicy tests the output of each case policy.
+func TestGenerateModN_CasePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		policy luhn.CasePolicy
+		want   string
+	}{
+		{"default lowercase", "hello", luhn.CaseDefault, "helloJ"},
+		{"default mixed", "HeLLo", luhn.CaseDefault, "HeLLoJ"},
+		{"strict upper", "HELLO", luhn.CaseStrictUpper, "HELLOJ"},
+		{"fold lowercase", "hello", luhn.CaseFold, "HELLOJ"},
+		{"fold mixed", "HeLLo", luhn.CaseFold, "HELLOJ"},
+		{"preserve lowercase", "hello", luhn.CasePreserve, "helloj"},
+		{"preserve uppercase", "HELLO", luhn.CasePreserve, "HELLOJ"},
+		{"preserve mixed", "HeLLo", luhn.CasePreserve, "HeLLoJ"},
+		{"preserve digits", "1234", luhn.CasePreserve, "1234" + mustChecksum(t, "1234")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := luhn.GenerateModN(tt.input, 36, false, luhn.WithCase(tt.policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateModN(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// mustChecksum returns the default mod-36 check character for value.
+func mustChecksum(t *testing.T, value string) string {
+	t.Helper()
+	c, err := luhn.GenerateModN(value, 36, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+// TestValidateModN_CasePolicy tests validation under each case policy.
+func TestValidateModN_CasePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		policy luhn.CasePolicy
+		want   bool
+	}{
+		{"default lowercase check", "helloj", luhn.CaseDefault, true},
+		{"default uppercase check", "helloJ", luhn.CaseDefault, true},
+		{"fold lowercase", "helloj", luhn.CaseFold, true},
+		{"strict upper", "HELLOJ", luhn.CaseStrictUpper, true},
+		{"preserve lowercase", "helloj", luhn.CasePreserve, true},
+		{"preserve uppercase", "HELLOJ", luhn.CasePreserve, true},
+		{"preserve mixed", "HeLLoJ", luhn.CasePreserve, true},
+		{"preserve wrong case", "helloJ", luhn.CasePreserve, false},
+		{"preserve wrong case upper", "HELLOj", luhn.CasePreserve, false},
+		{"preserve wrong char", "hellok", luhn.CasePreserve, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := luhn.ValidateModN(tt.input, 36, luhn.WithCase(tt.policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateModN(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCaseStrictUpper_Errors tests that CaseStrictUpper rejects lowercase input
+// after the usual character validation.
+func TestCaseStrictUpper_Errors(t *testing.T) {
+	opt := luhn.WithCase(luhn.CaseStrictUpper)
+
+	_, err := luhn.GenerateModN("ABc", 36, false, opt)
+	var ve *luhn.ValidationError
+	if !errors.As(err, &ve) || !errors.Is(err, luhn.ErrLowercase) {
+		t.Fatalf("got %v, want ErrLowercase", err)
+	}
+	if ve.Func != "GenerateModN" || ve.Reason != luhn.ReasonCase || ve.Index != 2 {
+		t.Errorf("got Func=%q Reason=%q Index=%d", ve.Func, ve.Reason, ve.Index)
+	}
+
+	if _, err := luhn.ValidateModN("helloJ", 36, opt); !errors.Is(err, luhn.ErrLowercase) {
+		t.Errorf("ValidateModN: got %v, want ErrLowercase", err)
+	}
+	if _, err := luhn.ChecksumModN("hello", 36, opt); !errors.Is(err, luhn.ErrLowercase) {
+		t.Errorf("ChecksumModN: got %v, want ErrLowercase", err)
+	}
+
+	// A character outside the alphabet is reported before the case error.
+	if _, err := luhn.GenerateModN("ab!", 36, false, opt); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("got %v, want ErrInvalidCharacter", err)
+	}
+	// Letters beyond n are invalid characters, not case errors.
+	if _, err := luhn.GenerateModN("z", 16, false, opt); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("got %v, want ErrInvalidCharacter", err)
+	}
+}
+
+// TestModN_Normalization tests that the normalization options apply to the
+// mod-N functions.
+func TestModN_Normalization(t *testing.T) {
+	opts := []luhn.Option{luhn.WithTrimSpace(), luhn.WithSeparators("-"), luhn.WithCase(luhn.CaseFold)}
+
+	got, err := luhn.GenerateModN(" ab-cd ", 36, false, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := luhn.GenerateModN("ABCD", 36, false)
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	ok, err := luhn.ValidateModN(" ab-cd"+want[4:]+" ", 36, opts...)
+	if err != nil || !ok {
+		t.Errorf("ValidateModN = %v, %v, want true", ok, err)
+	}
+
+	if _, err := luhn.ChecksumModN("ab/cd", 36, luhn.WithRejectChars("/")); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("got %v, want ErrInvalidCharacter", err)
+	}
+}
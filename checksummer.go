@@ -0,0 +1,137 @@
+package luhn
+
+import "unicode/utf8"
+
+// Checksummer computes a Luhn check character incrementally. Digits are
+// written left to right in arbitrary chunks through the io.Writer and
+// io.ByteWriter interfaces, so arbitrarily long streams can be checksummed
+// without buffering them and without the mod-N length limit.
+//
+// Because the doubling parity depends on the distance from the end of the
+// payload, which is unknown while streaming, the Checksummer tracks both
+// possible sums and picks the right one when Sum or Valid is called.
+type Checksummer struct {
+	alphabet *Alphabet
+	errFn    string
+	reason   Reason
+	err      error
+
+	// doubled is the running sum if the last written character is doubled
+	// (i.e. it ends the payload); single is the sum if it is not (i.e. it is
+	// followed by a check character).
+	doubled int
+	single  int
+	count   int
+	offset  int
+
+	// pending holds the leading bytes of a multi-byte character split across
+	// writes.
+	pending  [utf8.UTFMax]byte
+	npending int
+}
+
+// NewChecksummer returns a Checksummer for the standard mod-10 Luhn algorithm.
+// It accepts the digits 0-9 and rejects anything else with ErrNotNumeric.
+func NewChecksummer() *Checksummer {
+	return &Checksummer{alphabet: codePointAlphabets[10], errFn: "Checksummer.Write", reason: ReasonNotNumeric, err: ErrNotNumeric}
+}
+
+// NewChecksummerModN returns a Checksummer for the Luhn mod-N algorithm over
+// the CODE_POINTS alphabet. n must be between 1 and 36. Letters are accepted in
+// either case; characters outside the alphabet are rejected with
+// ErrInvalidCharacter.
+func NewChecksummerModN(n int) (*Checksummer, error) {
+	if n < 1 || n > 36 {
+		return nil, newValidationError("NewChecksummerModN", ReasonInvalidN, ErrInvalidN, "", -1)
+	}
+	return NewChecksummerAlphabet(codePointAlphabets[n]), nil
+}
+
+// NewChecksummerAlphabet returns a Checksummer for the Luhn mod-N algorithm
+// over a. Input is decoded as UTF-8, and multi-byte characters may be split
+// across writes. Characters outside a are rejected with ErrInvalidCharacter.
+func NewChecksummerAlphabet(a *Alphabet) *Checksummer {
+	return &Checksummer{alphabet: a, errFn: "Checksummer.Write", reason: ReasonInvalidCharacter, err: ErrInvalidCharacter}
+}
+
+// Write adds the characters in p to the running checksum. If p contains an
+// invalid character, Write returns the number of bytes consumed before it
+// and a *ValidationError whose Index and RuneIndex are offsets in the whole
+// stream. The count excludes every byte of the invalid character, so it is 0
+// if the character began in an earlier write.
+func (c *Checksummer) Write(p []byte) (int, error) {
+	for i, b := range p {
+		// Leading bytes of a multi-byte character are buffered, so the
+		// character starts npending bytes before b.
+		start := i - c.npending
+		if err := c.WriteByte(b); err != nil {
+			return max(start, 0), err
+		}
+	}
+	return len(p), nil
+}
+
+// WriteByte adds a single byte to the running checksum. Bytes of a
+// multi-byte character are buffered until the character is complete; if it
+// is invalid, the buffered bytes are discarded along with it.
+func (c *Checksummer) WriteByte(b byte) error {
+	if c.npending == 0 && b < utf8.RuneSelf {
+		return c.add(rune(b), 1)
+	}
+
+	c.pending[c.npending] = b
+	c.npending++
+	if !utf8.FullRune(c.pending[:c.npending]) {
+		return nil
+	}
+	r, size := utf8.DecodeRune(c.pending[:c.npending])
+	if size != c.npending {
+		// Invalid UTF-8: report the bytes decoded so far as a bad character.
+		r = utf8.RuneError
+	}
+	size, c.npending = c.npending, 0
+	return c.add(r, size)
+}
+
+// add adds the character r, whose UTF-8 encoding is size bytes long, to the
+// running checksum.
+func (c *Checksummer) add(r rune, size int) error {
+	idx := c.alphabet.IndexRune(r)
+	if idx < 0 {
+		char := byte(r)
+		if size > 1 || r >= utf8.RuneSelf {
+			char = c.pending[0]
+		}
+		return &ValidationError{Func: c.errFn, Reason: c.reason, Index: c.offset, Char: char, Rune: r, RuneIndex: c.count, Err: c.err}
+	}
+
+	n := c.alphabet.Len()
+	c.doubled, c.single = (c.single+contribution(idx, n, true))%n, (c.doubled+idx)%n
+	c.count++
+	c.offset += size
+	return nil
+}
+
+// Reset clears the Checksummer so it can be reused for a new stream.
+func (c *Checksummer) Reset() {
+	c.doubled, c.single, c.count, c.offset, c.npending = 0, 0, 0, 0, 0
+}
+
+// Len returns the number of characters written since the last Reset.
+func (c *Checksummer) Len() int {
+	return c.count
+}
+
+// Sum returns the index of the check character for the payload written so
+// far, ignoring any incomplete trailing multi-byte character. For mod-10 this
+// is the check digit itself.
+func (c *Checksummer) Sum() int {
+	n := c.alphabet.Len()
+	return (n - c.doubled) % n
+}
+
+// Valid reports whether the characters written so far end with a valid check
+// character. It returns false if fewer than two characters were written.
+func (c *Checksummer) Valid() bool {
+	return c.count >= 2 && c.single == 0
+}
@@ -0,0 +1,167 @@
+package luhn_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestChecksummerMatchesGenerate verifies that the Checksummer agrees with Generate
+// regardless of how the input is split into chunks.
+func TestChecksummerMatchesGenerate(t *testing.T) {
+	inputs := []string{"0", "1", "12", "123", "00123", "7992739871", "411111111111111"}
+
+	for _, input := range inputs {
+		want, err := luhn.ChecksumBytes([]byte(input))
+		if err != nil {
+			t.Fatalf("ChecksumBytes error: %v", err)
+		}
+		for chunk := 1; chunk <= len(input); chunk++ {
+			c := luhn.NewChecksummer()
+			for i := 0; i < len(input); i += chunk {
+				end := i + chunk
+				if end > len(input) {
+					end = len(input)
+				}
+				if _, err := c.Write([]byte(input[i:end])); err != nil {
+					t.Fatalf("Write error: %v", err)
+				}
+			}
+			if got := c.Sum(); got != want {
+				t.Errorf("Sum(%q) with chunk %d = %d, want %d", input, chunk, got, want)
+			}
+		}
+	}
+}
+
+// TestChecksummerValid verifies Valid against Validate.
+func TestChecksummerValid(t *testing.T) {
+	inputs := []string{"1", "18", "125", "1230", "001230", "10", "120", "1231"}
+
+	c := luhn.NewChecksummer()
+	for _, input := range inputs {
+		c.Reset()
+		if _, err := io.WriteString(c, input); err != nil {
+			t.Fatalf("WriteString error: %v", err)
+		}
+		want := false
+		if len(input) > 1 {
+			want, _ = luhn.Validate(input)
+		}
+		if got := c.Valid(); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+// TestChecksummerModN verifies that the mod-N Checksummer agrees with ChecksumModN.
+func TestChecksummerModN(t *testing.T) {
+	tests := []struct {
+		input string
+		n     int
+	}{
+		{"HELLO", 36},
+		{"hello", 36},
+		{"123ABC", 36},
+		{"FF", 16},
+		{"7992739871", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			want, err := luhn.ChecksumModN(tt.input, tt.n)
+			if err != nil {
+				t.Fatalf("ChecksumModN error: %v", err)
+			}
+			c, err := luhn.NewChecksummerModN(tt.n)
+			if err != nil {
+				t.Fatalf("NewChecksummerModN error: %v", err)
+			}
+			for i := 0; i < len(tt.input); i++ {
+				if err := c.WriteByte(tt.input[i]); err != nil {
+					t.Fatalf("WriteByte error: %v", err)
+				}
+			}
+			if got := c.Sum(); got != want {
+				t.Errorf("Sum = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestChecksummerLongStream verifies that streams beyond the mod-N length limit are accepted.
+func TestChecksummerLongStream(t *testing.T) {
+	payload := strings.Repeat("1234567890", 5000)
+	if _, err := luhn.ChecksumModN(payload, 10); !errors.Is(err, luhn.ErrModNMaxLength) {
+		t.Fatalf("expected ChecksumModN to reject the payload, got %v", err)
+	}
+
+	c, _ := luhn.NewChecksummerModN(10)
+	n, err := io.Copy(c, strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("io.Copy error: %v", err)
+	}
+	if n != int64(len(payload)) || c.Len() != len(payload) {
+		t.Errorf("copied %d bytes, Len() = %d, want %d", n, c.Len(), len(payload))
+	}
+
+	want, _ := luhn.Generate(payload, true)
+	if got := c.Sum(); string(rune('0'+got)) != want {
+		t.Errorf("Sum = %d, want %s", got, want)
+	}
+}
+
+// TestChecksummerErrors tests invalid characters and invalid n.
+func TestChecksummerErrors(t *testing.T) {
+	c := luhn.NewChecksummer()
+	_, _ = c.Write([]byte("123"))
+	n, err := c.Write([]byte("45a6"))
+	if n != 2 {
+		t.Errorf("Write returned n = %d, want 2", n)
+	}
+	var verr *luhn.ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, luhn.ErrNotNumeric) {
+		t.Fatalf("got %v, want ErrNotNumeric", err)
+	}
+	if verr.Index != 5 || verr.Char != 'a' {
+		t.Errorf("Index, Char = %d, %q, want 5, 'a'", verr.Index, verr.Char)
+	}
+
+	// A rejected multi-byte character is not counted as consumed.
+	for _, tt := range []struct {
+		input string
+		want  int
+	}{
+		{"12\u00e93", 2},
+		{"\u3042", 0},
+	} {
+		c.Reset()
+		n, err := c.Write([]byte(tt.input))
+		if n != tt.want || !errors.As(err, &verr) {
+			t.Errorf("Write(%q) = %d, %v, want %d and an error", tt.input, n, err, tt.want)
+			continue
+		}
+		if verr.Index != tt.want || verr.Rune != []rune(tt.input[tt.want:])[0] {
+			t.Errorf("Write(%q): Index, Rune = %d, %q", tt.input, verr.Index, verr.Rune)
+		}
+	}
+
+	// A character split across writes started in the earlier write.
+	c.Reset()
+	_, _ = c.Write([]byte("1\xe3\x81"))
+	if n, err := c.Write([]byte("\x822")); n != 0 || err == nil {
+		t.Errorf("Write = %d, %v, want 0 and an error", n, err)
+	}
+
+	m, _ := luhn.NewChecksummerModN(16)
+	if err := m.WriteByte('G'); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("got %v, want ErrInvalidCharacter", err)
+	}
+
+	if _, err := luhn.NewChecksummerModN(37); !errors.Is(err, luhn.ErrInvalidN) {
+		t.Errorf("got %v, want ErrInvalidN", err)
+	}
+}
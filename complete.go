@@ -0,0 +1,141 @@
+package luhn
+
+// Wildcard marks an unknown digit in the patterns accepted by Complete and
+// Completions.
+const Wildcard = '?'
+
+// undoubledDigit inverts doubledDigit: doubledDigit[undoubledDigit[t]] == t.
+var undoubledDigit = [10]int{0, 5, 1, 6, 2, 7, 3, 8, 4, 9}
+
+// parsePattern validates pattern, which must contain at least one Wildcard,
+// and returns it as a mutable buffer along with the wildcard positions.
+// Wildcards are validated as if they were digits, so the usual input errors
+// apply to the remaining characters.
+func parsePattern(fn, pattern string) ([]byte, []int, error) {
+	buf := []byte(pattern)
+	var wild []int
+	for i, c := range buf {
+		if c == Wildcard {
+			wild = append(wild, i)
+			buf[i] = '0'
+		}
+	}
+	if err := validateInput(fn, buf); err != nil {
+		return nil, nil, err
+	}
+	if len(buf) == 1 {
+		return nil, nil, newValidationError(fn, ReasonTooShort, ErrMinLength, pattern, -1)
+	}
+	if len(wild) == 0 {
+		return nil, nil, newValidationError(fn, ReasonWildcard, ErrNoWildcard, pattern, -1)
+	}
+	return buf, wild, nil
+}
+
+// solveWildcard sets buf[pos] to the only digit that makes buf valid. The
+// digit currently at pos is ignored.
+func solveWildcard(buf []byte, pos int) {
+	buf[pos] = '0'
+	last := len(buf) - 1
+	sum := sumDigitBytes(buf)
+	target := (10 - sum%10) % 10
+	if (last-pos)%2 == 1 {
+		target = undoubledDigit[target]
+	}
+	buf[pos] = byte('0' + target)
+}
+
+// sumDigitBytes returns the Luhn sum of a complete value whose last digit is
+// the check digit, i.e. with the second digit from the right doubled.
+func sumDigitBytes(buf []byte) int {
+	sum := 0
+	shouldDouble := false
+	for i := len(buf) - 1; i >= 0; i-- {
+		digit := int(buf[i] - '0')
+		if shouldDouble {
+			sum += doubledDigit[digit]
+		} else {
+			sum += digit
+		}
+		shouldDouble = !shouldDouble
+	}
+	return sum
+}
+
+// Complete replaces the single Wildcard in pattern with the only digit that
+// makes the result Luhn-valid, and returns the result. The wildcard may be at
+// any position, including the check digit. Use Completions for patterns with
+// more than one wildcard.
+func Complete(pattern string) (string, error) {
+	buf, wild, err := parsePattern("Complete", pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(wild) > 1 {
+		return "", newValidationError("Complete", ReasonWildcard, ErrMultipleWildcards, pattern, wild[1])
+	}
+	solveWildcard(buf, wild[0])
+	return string(buf), nil
+}
+
+// Completer enumerates the Luhn-valid completions of a pattern lazily. Use it
+// like bufio.Scanner:
+//
+//	c, err := luhn.Completions("4111??1111111111")
+//	for c.Next() {
+//		fmt.Println(c.Value())
+//	}
+//
+// For a pattern with k wildcards there are exactly 10^(k-1) completions,
+// produced in ascending order of all but the last wildcard.
+type Completer struct {
+	buf     []byte
+	wild    []int
+	started bool
+	done    bool
+}
+
+// Completions returns a Completer for pattern, which must contain at least
+// one Wildcard.
+func Completions(pattern string) (*Completer, error) {
+	buf, wild, err := parsePattern("Completions", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Completer{buf: buf, wild: wild}, nil
+}
+
+// Next advances to the next completion, returning false when there are no
+// more.
+func (c *Completer) Next() bool {
+	if c.done {
+		return false
+	}
+	if c.started && !c.increment() {
+		c.done = true
+		return false
+	}
+	c.started = true
+	solveWildcard(c.buf, c.wild[len(c.wild)-1])
+	return true
+}
+
+// increment advances the free wildcards (all but the last) like an odometer,
+// returning false once every combination has been produced.
+func (c *Completer) increment() bool {
+	for j := len(c.wild) - 2; j >= 0; j-- {
+		pos := c.wild[j]
+		if c.buf[pos] < '9' {
+			c.buf[pos]++
+			return true
+		}
+		c.buf[pos] = '0'
+	}
+	return false
+}
+
+// Value returns the current completion. It is only valid after Next returned
+// true.
+func (c *Completer) Value() string {
+	return string(c.buf)
+}
@@ -0,0 +1,98 @@
+package luhn_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestComplete verifies that Complete recovers a single missing digit at any position.
+func TestComplete(t *testing.T) {
+	valid := []string{"18", "1230", "001230", "79927398713", "4111111111111111"}
+
+	for _, v := range valid {
+		for i := range v {
+			pattern := v[:i] + "?" + v[i+1:]
+			t.Run(pattern, func(t *testing.T) {
+				got, err := luhn.Complete(pattern)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != v {
+					t.Errorf("Complete(%q) = %q, want %q", pattern, got, v)
+				}
+			})
+		}
+	}
+}
+
+// TestCompletions verifies that Completions yields exactly the valid completions, without duplicates.
+func TestCompletions(t *testing.T) {
+	patterns := []string{"1?3?", "??", "7992?39?71?", "4111111111111111?"}
+
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			c, err := luhn.Completions(pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			seen := make(map[string]bool)
+			for c.Next() {
+				v := c.Value()
+				if seen[v] {
+					t.Fatalf("duplicate completion %q", v)
+				}
+				seen[v] = true
+				if ok, err := luhn.Validate(v); err != nil || !ok {
+					t.Errorf("completion %q fails Validate", v)
+				}
+				for i := range pattern {
+					if pattern[i] != '?' && pattern[i] != v[i] {
+						t.Errorf("completion %q does not match %q", v, pattern)
+					}
+				}
+			}
+			if c.Next() {
+				t.Error("Next returned true after exhaustion")
+			}
+
+			want := 1
+			for i := 1; i < strings.Count(pattern, "?"); i++ {
+				want *= 10
+			}
+			if len(seen) != want {
+				t.Errorf("got %d completions, want %d", len(seen), want)
+			}
+		})
+	}
+}
+
+// TestCompleteErrors tests pattern validation.
+func TestCompleteErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    error
+	}{
+		{"empty", "", luhn.ErrEmpty},
+		{"single wildcard only", "?", luhn.ErrMinLength},
+		{"no wildcard", "1230", luhn.ErrNoWildcard},
+		{"multiple wildcards", "1??0", luhn.ErrMultipleWildcards},
+		{"non-numeric", "1a?0", luhn.ErrNotNumeric},
+		{"spaces", "1 ?0", luhn.ErrSpaces},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := luhn.Complete(tt.pattern); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := luhn.Completions("1230"); !errors.Is(err, luhn.ErrNoWildcard) {
+		t.Errorf("Completions: got %v, want %v", err, luhn.ErrNoWildcard)
+	}
+}
@@ -0,0 +1,191 @@
+package luhn
+
+import (
+	"crypto/subtle"
+	"unicode/utf8"
+)
+
+// The functions in this file compute checksums in constant time: for input of
+// a given length, the sequence of branches and memory accesses does not
+// depend on the characters of the input. Alphabet lookups scan the whole
+// ASCII index table rather than indexing it by a secret byte, and modular
+// reductions are done with masks rather than division.
+//
+// Only the ct-prefixed kernels are constant time. Once a scan completes, the
+// public functions may branch on its result to build an error, so invalid
+// input is reported in time that depends on what is wrong with it.
+// consttime_test.go checks the kernels for data-dependent branches.
+
+// ctFirst records, in constant time, the first index at which a condition held.
+type ctFirst struct {
+	index int
+	found int
+}
+
+// update records i if cond is 1 and no earlier index was recorded. cond must
+// be 0 or 1.
+func (f *ctFirst) update(cond, i int) {
+	hit := cond &^ f.found
+	f.index = subtle.ConstantTimeSelect(hit, i, f.index)
+	f.found |= cond
+}
+
+// ctScan is the result of scanning a value in constant time.
+type ctScan struct {
+	// sum is the Luhn sum of the value modulo N.
+	sum     int
+	space   ctFirst
+	minus   ctFirst
+	dot     ctFirst
+	invalid ctFirst
+}
+
+// ctIndex returns the position of c in a, or -1 if c is not in it. Every
+// entry of a's ASCII table is visited, so the memory access pattern does not
+// depend on c. a must be an ASCII alphabet.
+func ctIndex(a *Alphabet, c byte) int {
+	idx := 0
+	for j := 0; j < utf8.RuneSelf; j++ {
+		idx |= subtle.ConstantTimeSelect(subtle.ConstantTimeByteEq(c, byte(j)), int(a.ascii[j])+1, 0)
+	}
+	return idx - 1
+}
+
+// ctScanAlphabet computes the Luhn sum of value over the ASCII alphabet a,
+// doubling the last character if double is 1, and records the first space,
+// '-', '.' and character outside a. Characters outside a contribute zero to
+// the sum.
+func ctScanAlphabet(a *Alphabet, value string, double int) ctScan {
+	n := len(a.runes)
+	s := ctScan{
+		space:   ctFirst{index: -1},
+		minus:   ctFirst{index: -1},
+		dot:     ctFirst{index: -1},
+		invalid: ctFirst{index: -1},
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		s.space.update(subtle.ConstantTimeByteEq(c, ' '), i)
+		s.minus.update(subtle.ConstantTimeByteEq(c, '-'), i)
+		s.dot.update(subtle.ConstantTimeByteEq(c, '.'), i)
+
+		idx := ctIndex(a, c)
+		missing := subtle.ConstantTimeEq(int32(idx), -1)
+		s.invalid.update(missing, i)
+		idx = subtle.ConstantTimeSelect(missing, 0, idx)
+
+		// A doubled index d in [n, 2n) contributes d/n + d%n = d - n + 1.
+		doubled := 2 * idx
+		doubled -= (n - 1) * subtle.ConstantTimeLessOrEq(n, doubled)
+		dbl := double ^ ((len(value) - 1 - i) & 1)
+		s.sum += subtle.ConstantTimeSelect(dbl, doubled, idx)
+		s.sum -= n * subtle.ConstantTimeLessOrEq(n, s.sum)
+	}
+	return s
+}
+
+// ctCheck returns the check character index for a payload sum modulo n.
+func ctCheck(sum, n int) int {
+	check := n - sum
+	return check - n*subtle.ConstantTimeEq(int32(check), int32(n))
+}
+
+// decimalError returns the error for a failed mod-10 scan of value, in the
+// order of validateInput, or nil if the scan found no invalid character.
+func (s *ctScan) decimalError(fn, value string) error {
+	switch {
+	case s.space.found == 1:
+		return newValidationError(fn, ReasonSpaces, ErrSpaces, value, s.space.index)
+	case s.minus.found == 1:
+		return newValidationError(fn, ReasonNegative, ErrNegative, value, s.minus.index)
+	case s.dot.found == 1:
+		return newValidationError(fn, ReasonFloat, ErrFloat, value, s.dot.index)
+	case s.invalid.found == 1:
+		return newValidationError(fn, ReasonNotNumeric, ErrNotNumeric, value, s.invalid.index)
+	}
+	return nil
+}
+
+// modNError returns the error for a failed mod-N scan of value, in the order
+// of Alphabet.validateInput, or nil if the scan found no invalid character.
+func (s *ctScan) modNError(fn, value string) error {
+	switch {
+	case s.space.found == 1:
+		return newValidationError(fn, ReasonSpaces, ErrSpaces, value, s.space.index)
+	case s.invalid.found == 1:
+		return newValidationError(fn, ReasonInvalidCharacter, ErrInvalidCharacter, value, s.invalid.index)
+	}
+	return nil
+}
+
+// ValidateConstantTime is like Validate, but for valid input its running time
+// depends only on the length of value, not on its digits. It is an order
+// of magnitude slower than Validate.
+func ValidateConstantTime(value string) (bool, error) {
+	if value == "" {
+		return false, newValidationError("ValidateConstantTime", ReasonEmpty, ErrEmpty, value, -1)
+	}
+	s := ctScanAlphabet(codePointAlphabets[10], value, 0)
+	if err := s.decimalError("ValidateConstantTime", value); err != nil {
+		return false, err
+	}
+	if len(value) == 1 {
+		return false, newValidationError("ValidateConstantTime", ReasonTooShort, ErrMinLength, value, -1)
+	}
+	return subtle.ConstantTimeEq(int32(s.sum), 0) == 1, nil
+}
+
+// ChecksumConstantTime returns the Luhn check digit for value as an integer.
+// For valid input its running time depends only on the length of value.
+func ChecksumConstantTime(value string) (int, error) {
+	if value == "" {
+		return 0, newValidationError("ChecksumConstantTime", ReasonEmpty, ErrEmpty, value, -1)
+	}
+	s := ctScanAlphabet(codePointAlphabets[10], value, 1)
+	if err := s.decimalError("ChecksumConstantTime", value); err != nil {
+		return 0, err
+	}
+	return ctCheck(s.sum, 10), nil
+}
+
+// ValidateModNConstantTime is like ValidateModN without options, but for
+// valid input its running time depends only on n and the length of value.
+func ValidateModNConstantTime(value string, n int) (bool, error) {
+	if n < 1 || n > 36 {
+		return false, newValidationError("ValidateModNConstantTime", ReasonInvalidN, ErrInvalidN, value, -1)
+	}
+	if value == "" {
+		return false, newValidationError("ValidateModNConstantTime", ReasonEmpty, ErrEmpty, value, -1)
+	}
+	s := ctScanAlphabet(codePointAlphabets[n], value, 0)
+	if err := s.modNError("ValidateModNConstantTime", value); err != nil {
+		return false, err
+	}
+	// The alphabet is ASCII, so valid input has one byte per character.
+	if len(value) == 1 {
+		return false, newValidationError("ValidateModNConstantTime", ReasonTooShort, ErrMinLength, value, -1)
+	}
+	if len(value) >= modNMaxLength {
+		return false, newValidationError("ValidateModNConstantTime", ReasonTooLong, ErrModNMaxLength, value, -1)
+	}
+	return subtle.ConstantTimeEq(int32(s.sum), 0) == 1, nil
+}
+
+// ChecksumModNConstantTime is like ChecksumModN without options, but for
+// valid input its running time depends only on n and the length of value.
+func ChecksumModNConstantTime(value string, n int) (int, error) {
+	if n < 1 || n > 36 {
+		return 0, newValidationError("ChecksumModNConstantTime", ReasonInvalidN, ErrInvalidN, value, -1)
+	}
+	if value == "" {
+		return 0, newValidationError("ChecksumModNConstantTime", ReasonEmpty, ErrEmpty, value, -1)
+	}
+	s := ctScanAlphabet(codePointAlphabets[n], value, 1)
+	if err := s.modNError("ChecksumModNConstantTime", value); err != nil {
+		return 0, err
+	}
+	if len(value) >= modNMaxLength {
+		return 0, newValidationError("ChecksumModNConstantTime", ReasonTooLong, ErrModNMaxLength, value, -1)
+	}
+	return ctCheck(s.sum, n), nil
+}
@@ -0,0 +1,483 @@
+package luhn_test
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math/rand"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// ctInputs returns mod-10 and mod-N test inputs, including invalid ones.
+func ctInputs() []string {
+	inputs := []string{
+		"", "0", "7", "00", "18", "79927398713", "79927398710", "4111111111111111",
+		"4111 1111", "-12", "1.5", "12a4", "1a-.", "A1B2C3", "abc", "hello", "helloJ",
+		"ZZZZ", "a b", "é1", "12é", strings.Repeat("9", 10000),
+	}
+	r := rand.New(rand.NewSource(1))
+	const chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcz"
+	for i := 0; i < 500; i++ {
+		b := make([]byte, 2+r.Intn(20))
+		for j := range b {
+			b[j] = chars[r.Intn(10+r.Intn(len(chars)-9))]
+		}
+		inputs = append(inputs, string(b))
+	}
+	return inputs
+}
+
+// sameResult reports whether two results and errors agree, ignoring the
+// function name of validation errors.
+func sameResult(got, want any, gotErr, wantErr error) bool {
+	if (gotErr == nil) != (wantErr == nil) {
+		return false
+	}
+	if gotErr == nil {
+		return got == want
+	}
+	var g, w *luhn.ValidationError
+	if !errors.As(gotErr, &g) || !errors.As(wantErr, &w) {
+		return false
+	}
+	return g.Err == w.Err && g.Index == w.Index
+}
+
+// TestConstantTime_MatchesVariableTime tests that the constant-time functions
+// return the same results and errors as their variable-time counterparts.
+func TestConstantTime_MatchesVariableTime(t *testing.T) {
+	for _, in := range ctInputs() {
+		got, gotErr := luhn.ValidateConstantTime(in)
+		want, wantErr := luhn.Validate(in)
+		if !sameResult(got, want, gotErr, wantErr) {
+			t.Errorf("ValidateConstantTime(%.20q) = %v, %v; Validate = %v, %v", in, got, gotErr, want, wantErr)
+		}
+
+		gotSum, gotErr := luhn.ChecksumConstantTime(in)
+		wantSum, wantErr := luhn.Generate(in, true)
+		if wantErr == nil {
+			wantSum = fmt.Sprint(wantSum)
+		}
+		if !sameResult(fmt.Sprint(gotSum), wantSum, gotErr, wantErr) {
+			t.Errorf("ChecksumConstantTime(%.20q) = %v, %v; Generate = %v, %v", in, gotSum, gotErr, wantSum, wantErr)
+		}
+
+		for _, n := range []int{1, 10, 16, 36} {
+			got, gotErr := luhn.ValidateModNConstantTime(in, n)
+			want, wantErr := luhn.ValidateModN(in, n)
+			if !sameResult(got, want, gotErr, wantErr) {
+				t.Errorf("ValidateModNConstantTime(%.20q, %d) = %v, %v; ValidateModN = %v, %v", in, n, got, gotErr, want, wantErr)
+			}
+
+			gotSum, gotErr := luhn.ChecksumModNConstantTime(in, n)
+			wantSum, wantErr := luhn.ChecksumModN(in, n)
+			if !sameResult(gotSum, wantSum, gotErr, wantErr) {
+				t.Errorf("ChecksumModNConstantTime(%.20q, %d) = %v, %v; ChecksumModN = %v, %v", in, n, gotSum, gotErr, wantSum, wantErr)
+			}
+		}
+	}
+}
+
+// TestConstantTime_Errors tests the function names and invalid n handling.
+func TestConstantTime_Errors(t *testing.T) {
+	var ve *luhn.ValidationError
+	if _, err := luhn.ValidateConstantTime("12a"); !errors.As(err, &ve) || ve.Func != "ValidateConstantTime" {
+		t.Errorf("got %v, want error from ValidateConstantTime", err)
+	}
+	if _, err := luhn.ValidateModNConstantTime("12", 37); !errors.Is(err, luhn.ErrInvalidN) {
+		t.Errorf("got %v, want ErrInvalidN", err)
+	}
+	if _, err := luhn.ChecksumModNConstantTime("12", 0); !errors.Is(err, luhn.ErrInvalidN) {
+		t.Errorf("got %v, want ErrInvalidN", err)
+	}
+}
+
+// ctKernels lists the constant-time kernels in consttime.go and, for each,
+// the parameters that carry secret data. The contents of a secret string are
+// secret but its length is public.
+var ctKernels = map[string][]string{
+	"ctFirst.update": {"cond"},
+	"ctIndex":        {"c"},
+	"ctScanAlphabet": {"value"},
+	"ctCheck":        {"sum"},
+}
+
+// TestConstantTime_NoSecretBranches checks the kernels for data-dependent
+// branches, memory accesses, divisions and calls.
+func TestConstantTime_NoSecretBranches(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "consttime.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if ok && strings.HasPrefix(fd.Name.Name, "ct") {
+			if _, listed := ctKernels[funcName(fd)]; !listed {
+				t.Errorf("%s is not listed in ctKernels", funcName(fd))
+			}
+		}
+	}
+	problems := checkConstantTime(fset, f, ctKernels)
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+// TestConstantTime_Checker tests that the checker detects leaks.
+func TestConstantTime_Checker(t *testing.T) {
+	const src = `package p
+
+var table = [10]int{}
+
+func ctBranch(value string) int {
+	n := 0
+	for i := 0; i < len(value); i++ {
+		d := int(value[i] - '0')
+		if d > 4 {
+			n++
+		}
+	}
+	return n
+}
+
+func ctLookup(value string) int {
+	return table[value[0]-'0']
+}
+
+func ctDivide(value string, n int) int {
+	sum := int(value[0])
+	return sum % n
+}
+
+func ctRange(value string) int {
+	for range value {
+	}
+	return 0
+}
+
+func ctCall(value string) int {
+	return ctLookup(value) + strings.IndexByte(value, 'x')
+}
+
+func ctClean(value string, n int) int {
+	sum := 0
+	for i := 0; i < len(value); i++ {
+		sum += int(value[i]) & n
+	}
+	return sum
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "leaky.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kernels := map[string][]string{
+		"ctBranch": {"value"},
+		"ctLookup": {"value"},
+		"ctDivide": {"value"},
+		"ctRange":  {"value"},
+		"ctCall":   {"value"},
+		"ctClean":  {"value"},
+	}
+	problems := strings.Join(checkConstantTime(fset, f, kernels), "\n")
+	for _, fn := range []string{"ctBranch", "ctLookup", "ctDivide", "ctRange", "ctCall"} {
+		if !strings.Contains(problems, fn+":") {
+			t.Errorf("no problem reported for %s in:\n%s", fn, problems)
+		}
+	}
+	if strings.Contains(problems, "ctClean:") {
+		t.Errorf("problem reported for ctClean:\n%s", problems)
+	}
+}
+
+// funcName returns the name of fd, qualified by its receiver type if any.
+func funcName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	typ := fd.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	return exprPath(typ) + "." + fd.Name.Name
+}
+
+// exprPath returns the dotted path of an identifier or selector expression,
+// or "" for other expressions.
+func exprPath(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.ParenExpr:
+		return exprPath(e.X)
+	case *ast.StarExpr:
+		return exprPath(e.X)
+	case *ast.SelectorExpr:
+		if x := exprPath(e.X); x != "" {
+			return x + "." + e.Sel.Name
+		}
+	}
+	return ""
+}
+
+// checkConstantTime performs a taint analysis of the kernels in f. Values
+// derived from secret parameters must not reach a branch condition, an index
+// or slice bound, a comparison, a division, a range loop, or a call other
+// than to a conversion, len, crypto/subtle or a secret parameter of another
+// kernel. It returns a description of each violation.
+func checkConstantTime(fset *token.FileSet, f *ast.File, kernels map[string][]string) []string {
+	params := map[string][]string{}
+	var decls []*ast.FuncDecl
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if _, ok := kernels[funcName(fd)]; !ok {
+			continue
+		}
+		decls = append(decls, fd)
+		for _, field := range fd.Type.Params.List {
+			for _, name := range field.Names {
+				params[funcName(fd)] = append(params[funcName(fd)], name.Name)
+			}
+		}
+	}
+
+	var problems []string
+	for _, fd := range decls {
+		c := &taintChecker{fset: fset, fn: funcName(fd), kernels: kernels, params: params, tainted: map[string]bool{}}
+		for _, p := range kernels[c.fn] {
+			c.tainted[p] = true
+		}
+		c.propagate(fd.Body)
+		c.check(fd.Body)
+		problems = append(problems, c.problems...)
+	}
+	return problems
+}
+
+// taintChecker tracks the secret variables of one kernel.
+type taintChecker struct {
+	fset     *token.FileSet
+	fn       string
+	kernels  map[string][]string
+	params   map[string][]string
+	tainted  map[string]bool
+	problems []string
+}
+
+// isTainted reports whether e depends on secret data.
+func (c *taintChecker) isTainted(e ast.Expr) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			// The length of a secret value is public.
+			if id, ok := n.Fun.(*ast.Ident); ok && id.Name == "len" {
+				return false
+			}
+		case *ast.Ident, *ast.SelectorExpr:
+			p := exprPath(n.(ast.Expr))
+			if p == "" {
+				return true
+			}
+			for {
+				if c.tainted[p] {
+					found = true
+					break
+				}
+				i := strings.LastIndexByte(p, '.')
+				if i < 0 {
+					break
+				}
+				p = p[:i]
+			}
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// taint marks the variable or field e as secret, reporting whether it was
+// not already.
+func (c *taintChecker) taint(e ast.Expr) bool {
+	p := exprPath(e)
+	if p == "" || p == "_" || c.tainted[p] {
+		return false
+	}
+	c.tainted[p] = true
+	return true
+}
+
+// propagate marks every variable assigned from secret data as secret,
+// iterating until no more variables are marked.
+func (c *taintChecker) propagate(body *ast.BlockStmt) {
+	for changed := true; changed; {
+		changed = false
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					rhs := n.Rhs[0]
+					if len(n.Rhs) == len(n.Lhs) {
+						rhs = n.Rhs[i]
+					}
+					if c.isTainted(rhs) && c.taint(lhs) {
+						changed = true
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if i < len(n.Values) && c.isTainted(n.Values[i]) && c.taint(name) {
+						changed = true
+					}
+				}
+			case *ast.RangeStmt:
+				if c.isTainted(n.X) {
+					if n.Key != nil && c.taint(n.Key) {
+						changed = true
+					}
+					if n.Value != nil && c.taint(n.Value) {
+						changed = true
+					}
+				}
+			case *ast.CallExpr:
+				// A method called with secret arguments may store them in its
+				// receiver.
+				if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+					for _, arg := range n.Args {
+						if c.isTainted(arg) && c.taint(sel.X) {
+							changed = true
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// check reports the uses of secret data that may leak through timing.
+func (c *taintChecker) check(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			c.require(n.Cond, "branch condition")
+		case *ast.ForStmt:
+			if n.Cond != nil {
+				c.require(n.Cond, "loop condition")
+			}
+		case *ast.RangeStmt:
+			c.require(n.X, "range expression")
+		case *ast.SwitchStmt:
+			if n.Tag != nil {
+				c.require(n.Tag, "switch tag")
+			}
+		case *ast.CaseClause:
+			for _, e := range n.List {
+				c.require(e, "case expression")
+			}
+		case *ast.IndexExpr:
+			c.require(n.Index, "index")
+		case *ast.SliceExpr:
+			for _, e := range []ast.Expr{n.Low, n.High, n.Max} {
+				if e != nil {
+					c.require(e, "slice bound")
+				}
+			}
+		case *ast.BinaryExpr:
+			switch n.Op {
+			case token.LAND, token.LOR, token.EQL, token.NEQ, token.LSS, token.LEQ,
+				token.GTR, token.GEQ, token.QUO, token.REM:
+				c.require(n.X, "operand of "+n.Op.String())
+				c.require(n.Y, "operand of "+n.Op.String())
+			}
+		case *ast.CallExpr:
+			c.checkCall(n)
+		}
+		return true
+	})
+}
+
+// checkCall reports secret arguments passed to a function that is not known
+// to handle them in constant time.
+func (c *taintChecker) checkCall(call *ast.CallExpr) {
+	name := exprPath(call.Fun)
+	switch name {
+	case "len", "int", "int32", "byte", "uint8", "rune", "uint64":
+		return
+	}
+	if strings.HasPrefix(name, "subtle.") {
+		return
+	}
+
+	callee := ""
+	if _, ok := c.kernels[name]; ok {
+		callee = name
+	} else if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		for k := range c.kernels {
+			if strings.HasSuffix(k, "."+sel.Sel.Name) {
+				callee = k
+			}
+		}
+	}
+
+	for i, arg := range call.Args {
+		if !c.isTainted(arg) {
+			continue
+		}
+		if callee != "" && i < len(c.params[callee]) && contains(c.kernels[callee], c.params[callee][i]) {
+			continue
+		}
+		c.report(arg, "argument to "+name)
+	}
+}
+
+// require reports e if it depends on secret data.
+func (c *taintChecker) require(e ast.Expr, what string) {
+	if c.isTainted(e) {
+		c.report(e, what)
+	}
+}
+
+// report records a violation at e.
+func (c *taintChecker) report(e ast.Expr, what string) {
+	c.problems = append(c.problems, fmt.Sprintf("%s: %s: secret data in %s", c.fn, c.fset.Position(e.Pos()), what))
+}
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func BenchmarkValidateConstantTime(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.ValidateConstantTime("4111111111111111")
+	}
+}
+
+func BenchmarkValidateModNConstantTime(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = luhn.ValidateModNConstantTime("HELLOJ", 36)
+	}
+}
@@ -0,0 +1,172 @@
+package luhn
+
+// dammTable10 is the order-10 weakly totally anti-symmetric quasigroup from
+// Damm's thesis, which detects all single-digit errors and all adjacent
+// transpositions.
+var dammTable10 = [][]int{
+	{0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+	{7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+	{4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+	{1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+	{6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+	{3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+	{5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+	{8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+	{9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+	{2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+// dammDecimal backs GenerateDamm, ValidateDamm and ChecksumDamm.
+var dammDecimal = func() *Damm {
+	d, err := NewDamm(codePointAlphabets[10], dammTable10)
+	if err != nil {
+		panic(err)
+	}
+	d.decimal = true
+	return d
+}()
+
+// Damm is the Damm check character algorithm over an alphabet, defined by a
+// quasigroup operation table. It implements Scheme, and like every Scheme its
+// Compute, Generate and Validate methods report errors as coming from
+// "Scheme.Compute", "Scheme.Generate" and "Scheme.Validate".
+type Damm struct {
+	alphabet *Alphabet
+	table    []int
+	// zeros[x] is the column c with x*c = 0, the check character for
+	// interim x.
+	zeros   []int
+	decimal bool
+}
+
+// NewDamm returns the Damm algorithm over a using the quasigroup table, where
+// table[x][y] is the index of x*y. The table must be a Latin square of order
+// a.Len(), i.e. any quasigroup; the check character for a value is the one
+// that reduces it to zero. For the algorithm to detect all adjacent
+// transpositions the quasigroup must also be weakly totally anti-symmetric;
+// NewDamm does not check this. Returns ErrInvalidTable otherwise.
+func NewDamm(a *Alphabet, table [][]int) (*Damm, error) {
+	n := a.Len()
+	if len(table) != n {
+		return nil, newValidationError("NewDamm", ReasonInvalidTable, ErrInvalidTable, "", -1)
+	}
+	d := &Damm{alphabet: a, table: make([]int, 0, n*n), zeros: make([]int, n)}
+	cols := make([]bool, n*n)
+	for x, row := range table {
+		if len(row) != n {
+			return nil, newValidationError("NewDamm", ReasonInvalidTable, ErrInvalidTable, "", -1)
+		}
+		seen := make([]bool, n)
+		for y, v := range row {
+			// Each value must appear once per row and once per column.
+			if v < 0 || v >= n || seen[v] || cols[y*n+v] {
+				return nil, newValidationError("NewDamm", ReasonInvalidTable, ErrInvalidTable, "", -1)
+			}
+			seen[v] = true
+			cols[y*n+v] = true
+			if v == 0 {
+				d.zeros[x] = y
+			}
+		}
+		d.table = append(d.table, row...)
+	}
+	return d, nil
+}
+
+// interim returns the interim digit after processing value, which must have
+// passed input validation.
+func (d *Damm) interim(value string) int {
+	n := d.alphabet.Len()
+	x := 0
+	for _, r := range value {
+		x = d.table[x*n+d.alphabet.IndexRune(r)]
+	}
+	return x
+}
+
+// validateInput validates value as GenerateDamm does for the decimal table,
+// or against the alphabet otherwise.
+func (d *Damm) validateInput(fn, value string) error {
+	if d.decimal {
+		return validateInput(fn, value)
+	}
+	_, err := d.alphabet.validateInput(fn, value)
+	return err
+}
+
+// Alphabet returns the alphabet of d.
+func (d *Damm) Alphabet() *Alphabet {
+	return d.alphabet
+}
+
+// Checksum returns the index of the Damm check character for value.
+func (d *Damm) Checksum(value string) (int, error) {
+	return d.checksum("Damm.Checksum", value)
+}
+
+// checksum implements Damm.Checksum, reporting errors as coming from fn.
+func (d *Damm) checksum(fn, value string) (int, error) {
+	if err := d.validateInput(fn, value); err != nil {
+		return 0, err
+	}
+	return d.zeros[d.interim(value)], nil
+}
+
+// Compute returns the Damm check character for value.
+func (d *Damm) Compute(value string) (string, error) {
+	return d.generate("Scheme.Compute", value, true)
+}
+
+// Generate returns value followed by its Damm check character.
+func (d *Damm) Generate(value string) (string, error) {
+	return d.generate("Scheme.Generate", value, false)
+}
+
+// generate implements Damm.Compute and Damm.Generate, reporting errors as coming from fn.
+func (d *Damm) generate(fn, value string, checksumOnly bool) (string, error) {
+	idx, err := d.checksum(fn, value)
+	if err != nil {
+		return "", err
+	}
+	check := string(d.alphabet.runes[idx])
+	if checksumOnly {
+		return check, nil
+	}
+	return value + check, nil
+}
+
+// Validate determines whether value has a valid Damm check character as its
+// last character.
+func (d *Damm) Validate(value string) (bool, error) {
+	return d.validate("Scheme.Validate", value)
+}
+
+// validate implements Damm.Validate, reporting errors as coming from fn.
+func (d *Damm) validate(fn, value string) (bool, error) {
+	if err := d.validateInput(fn, value); err != nil {
+		return false, err
+	}
+	if len([]rune(value)) == 1 {
+		return false, newValidationError(fn, ReasonTooShort, ErrMinLength, value, -1)
+	}
+	return d.interim(value) == 0, nil
+}
+
+// GenerateDamm calculates and appends a Damm check digit to value.
+// If checksumOnly is true, only the check digit is returned. Input is
+// validated as by Generate.
+func GenerateDamm(value string, checksumOnly bool) (string, error) {
+	return dammDecimal.generate("GenerateDamm", value, checksumOnly)
+}
+
+// ValidateDamm determines whether value has a valid Damm check digit as its
+// last character. Returns an error if value fails input validation or has
+// length 1.
+func ValidateDamm(value string) (bool, error) {
+	return dammDecimal.validate("ValidateDamm", value)
+}
+
+// ChecksumDamm returns the Damm check digit for value as an integer.
+func ChecksumDamm(value string) (int, error) {
+	return dammDecimal.checksum("ChecksumDamm", value)
+}
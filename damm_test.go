@@ -0,0 +1,226 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestGenerateDamm tests Damm check digits against published examples.
+func TestGenerateDamm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"572", "5724"},
+		{"0", "00"},
+		{"43881234567", "438812345679"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := luhn.GenerateDamm(tt.input, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateDamm(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			check, err := luhn.GenerateDamm(tt.input, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := tt.want[len(tt.input):]; check != want {
+				t.Errorf("GenerateDamm(%q, true) = %q, want %q", tt.input, check, want)
+			}
+
+			n, err := luhn.ChecksumDamm(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := int(tt.want[len(tt.want)-1] - '0'); n != want {
+				t.Errorf("ChecksumDamm(%q) = %d, want %d", tt.input, n, want)
+			}
+
+			if ok, err := luhn.ValidateDamm(got); err != nil || !ok {
+				t.Errorf("ValidateDamm(%q) = %v, %v, want true", got, ok, err)
+			}
+		})
+	}
+}
+
+// TestDamm_DetectsErrors tests that every single-digit error and adjacent
+// transposition of a valid value is rejected.
+func TestDamm_DetectsErrors(t *testing.T) {
+	valid, err := luhn.GenerateDamm("4388123456", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < len(valid); i++ {
+		for c := byte('0'); c <= '9'; c++ {
+			if c == valid[i] {
+				continue
+			}
+			edited := []byte(valid)
+			edited[i] = c
+			if ok, _ := luhn.ValidateDamm(string(edited)); ok {
+				t.Errorf("ValidateDamm(%q) = true for substitution in %q", edited, valid)
+			}
+		}
+		if i+1 < len(valid) && valid[i] != valid[i+1] {
+			edited := []byte(valid)
+			edited[i], edited[i+1] = edited[i+1], edited[i]
+			if ok, _ := luhn.ValidateDamm(string(edited)); ok {
+				t.Errorf("ValidateDamm(%q) = true for transposition in %q", edited, valid)
+			}
+		}
+	}
+}
+
+// TestDamm_Errors tests that Damm shares the Luhn input validation.
+func TestDamm_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr error
+		wantFn  string
+	}{
+		{"generate empty", func() error { _, err := luhn.GenerateDamm("", false); return err }, luhn.ErrEmpty, "GenerateDamm"},
+		{"generate negative", func() error { _, err := luhn.GenerateDamm("-1", false); return err }, luhn.ErrNegative, "GenerateDamm"},
+		{"validate not numeric", func() error { _, err := luhn.ValidateDamm("12a"); return err }, luhn.ErrNotNumeric, "ValidateDamm"},
+		{"validate too short", func() error { _, err := luhn.ValidateDamm("0"); return err }, luhn.ErrMinLength, "ValidateDamm"},
+		{"checksum spaces", func() error { _, err := luhn.ChecksumDamm("1 2"); return err }, luhn.ErrSpaces, "ChecksumDamm"},
+		{"scheme", func() error { _, err := luhn.Lookup("damm"); return err }, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if ve.Func != tt.wantFn {
+				t.Errorf("Func = %q, want %q", ve.Func, tt.wantFn)
+			}
+		})
+	}
+}
+
+// xorTable returns the order-n table x XOR y, a quasigroup with a zero
+// diagonal for n a power of two.
+func xorTable(n int) [][]int {
+	table := make([][]int, n)
+	for x := range table {
+		table[x] = make([]int, n)
+		for y := range table[x] {
+			table[x][y] = x ^ y
+		}
+	}
+	return table
+}
+
+// TestNewDamm tests a user-supplied quasigroup over a custom alphabet.
+func TestNewDamm(t *testing.T) {
+	a, err := luhn.NewAlphabet("ABCD", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, err := luhn.NewDamm(a, xorTable(4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := d.Generate("BCD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "BCDA" {
+		t.Errorf("Generate = %q, want %q", got, "BCDA")
+	}
+	if ok, err := d.Validate("bcda"); err != nil || !ok {
+		t.Errorf("Validate = %v, %v, want true", ok, err)
+	}
+	if ok, _ := d.Validate("BCDB"); ok {
+		t.Error("Validate(BCDB) = true, want false")
+	}
+	if idx, _ := d.Checksum("AB"); idx != 1 {
+		t.Errorf("Checksum = %d, want 1", idx)
+	}
+	if _, err := d.Compute("ABE"); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("got %v, want ErrInvalidCharacter", err)
+	}
+
+	var s luhn.Scheme = d
+	if s.Alphabet() != a {
+		t.Error("Alphabet does not return the alphabet")
+	}
+}
+
+// TestNewDamm_NonzeroDiagonal tests a quasigroup whose diagonal is not zero:
+// addition modulo 4, where the check character for interim x is -x.
+func TestNewDamm_NonzeroDiagonal(t *testing.T) {
+	a, _ := luhn.NewAlphabet("ABCD", false)
+	d, err := luhn.NewDamm(a, [][]int{
+		{0, 1, 2, 3},
+		{1, 2, 3, 0},
+		{2, 3, 0, 1},
+		{3, 0, 1, 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// B+C+D = 1+2+3 = 2 (mod 4), so the check character is C.
+	code, err := d.Generate("BCD")
+	if err != nil || code != "BCDC" {
+		t.Fatalf("Generate = %q, %v, want BCDC", code, err)
+	}
+	if idx, _ := d.Checksum("BCD"); idx != 2 {
+		t.Errorf("Checksum = %d, want 2", idx)
+	}
+	for _, v := range []string{"BCDC", "AA", "BD", "DDDD"} {
+		if ok, err := d.Validate(v); !ok || err != nil {
+			t.Errorf("Validate(%q) = %v, %v, want true", v, ok, err)
+		}
+	}
+	if ok, _ := d.Validate("BCDB"); ok {
+		t.Error("Validate(BCDB) = true, want false")
+	}
+}
+
+// TestNewDamm_InvalidTable tests that malformed tables are rejected.
+func TestNewDamm_InvalidTable(t *testing.T) {
+	a, _ := luhn.NewAlphabet("ABCD", false)
+	tests := []struct {
+		name  string
+		table [][]int
+	}{
+		{"wrong order", xorTable(2)},
+		{"short row", [][]int{{0, 1, 2, 3}, {1, 0, 3}, {2, 3, 0, 1}, {3, 2, 1, 0}}},
+		{"out of range", [][]int{{0, 1, 2, 4}, {1, 0, 3, 2}, {2, 3, 0, 1}, {3, 2, 1, 0}}},
+		{"repeated in row", [][]int{{0, 1, 1, 3}, {1, 0, 3, 2}, {2, 3, 0, 1}, {3, 2, 1, 0}}},
+		{"repeated in column", [][]int{{0, 1, 2, 3}, {1, 0, 3, 2}, {2, 3, 0, 1}, {2, 3, 1, 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := luhn.NewDamm(a, tt.table)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, luhn.ErrInvalidTable) {
+				t.Fatalf("got %v, want ErrInvalidTable", err)
+			}
+			if ve.Func != "NewDamm" || ve.Reason != luhn.ReasonInvalidTable {
+				t.Errorf("got Func=%q Reason=%q", ve.Func, ve.Reason)
+			}
+		})
+	}
+}
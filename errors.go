@@ -0,0 +1,136 @@
+package luhn
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Sentinel errors returned (wrapped in a *ValidationError) by the public
+// functions. Use errors.Is to test for a specific failure.
+var (
+	ErrEmpty             = errors.New("string cannot be empty")
+	ErrSpaces            = errors.New("string cannot contain spaces")
+	ErrNegative          = errors.New("negative numbers are not allowed")
+	ErrFloat             = errors.New("floating point numbers are not allowed")
+	ErrNotNumeric        = errors.New("string must be convertible to a number")
+	ErrInvalidCharacter  = errors.New("invalid character")
+	ErrMinLength         = errors.New("string must be longer than 1 character")
+	ErrCheckLength       = errors.New("string must be longer than its check characters")
+	ErrRandomMax         = errors.New("string must be less than 100 characters")
+	ErrRandomMin         = errors.New("string must be greater than 1")
+	ErrInvalidN          = errors.New("n must be between 1 and 36")
+	ErrModNMaxLength     = errors.New("string must be less than 10000 characters")
+	ErrOverflow          = errors.New("result overflows uint64")
+	ErrPrefixTooLong     = errors.New("prefix must be shorter than length")
+	ErrInvalidCount      = errors.New("count must not be negative")
+	ErrInvalidBound      = errors.New("bound must be positive")
+	ErrKeyspace          = errors.New("count exceeds the number of distinct values")
+	ErrNoWildcard        = errors.New("pattern must contain a wildcard")
+	ErrMultipleWildcards = errors.New("pattern must contain exactly one wildcard")
+	ErrDuplicateChar     = errors.New("alphabet contains a duplicate character")
+	ErrEmptyAlphabet     = errors.New("alphabet has no characters")
+	ErrLowercase         = errors.New("lowercase characters are not allowed")
+	ErrUnknownScheme     = errors.New("unknown check digit scheme")
+	ErrInvalidTable      = errors.New("table must be a Latin square")
+	ErrNPILength         = errors.New("invalid NPI length")
+	ErrNPIPrefix         = errors.New("card issuer form NPI must start with 80840")
+	ErrNPIFirstDigit     = errors.New("NPI must start with 1 or 2")
+)
+
+// Reason is a machine-readable code identifying which validation check failed.
+type Reason string
+
+// Reason codes carried by ValidationError.
+const (
+	ReasonEmpty            Reason = "empty"
+	ReasonSpaces           Reason = "spaces"
+	ReasonNegative         Reason = "negative"
+	ReasonFloat            Reason = "float"
+	ReasonNotNumeric       Reason = "not_numeric"
+	ReasonInvalidCharacter Reason = "invalid_character"
+	ReasonTooShort         Reason = "too_short"
+	ReasonTooLong          Reason = "too_long"
+	ReasonOutOfRange       Reason = "out_of_range"
+	ReasonInvalidN         Reason = "invalid_n"
+	ReasonWildcard         Reason = "wildcard"
+	ReasonDuplicate        Reason = "duplicate"
+	ReasonCase             Reason = "case"
+	ReasonUnknownScheme    Reason = "unknown_scheme"
+	ReasonInvalidTable     Reason = "invalid_table"
+	ReasonLength           Reason = "length"
+	ReasonCheckDigit       Reason = "check_digit"
+	ReasonPrefix           Reason = "prefix"
+	ReasonFirstDigit       Reason = "first_digit"
+)
+
+// ValidationError describes an input rejected by one of the public functions.
+// It wraps one of the Err* sentinels, so both errors.Is and errors.As work.
+type ValidationError struct {
+	// Func is the name of the public function that rejected the input.
+	Func string
+	// Reason identifies the failed check.
+	Reason Reason
+	// Index is the byte offset of the offending character, or -1 if the
+	// check does not concern a single character.
+	Index int
+	// Char is the offending byte, or 0 if Index is -1. For a multi-byte
+	// character it is the first byte of its UTF-8 encoding.
+	Char byte
+	// Rune is the offending character, or 0 if Index is -1. It is
+	// utf8.RuneError if the input is not valid UTF-8 at Index.
+	Rune rune
+	// RuneIndex is the offset of the offending character in runes, or -1 if
+	// Index is -1.
+	RuneIndex int
+	// Err is the underlying sentinel error.
+	Err error
+}
+
+// Error returns the message of the underlying sentinel. Invalid character
+// errors also include the offending character.
+func (e *ValidationError) Error() string {
+	if e.Reason == ReasonInvalidCharacter {
+		if e.Rune >= utf8.RuneSelf && e.Rune != utf8.RuneError {
+			return fmt.Sprintf("%s: %q", e.Err, e.Rune)
+		}
+		return fmt.Sprintf("%s: %q", e.Err, e.Char)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// WithFunc returns a copy of e that names fn as the rejecting function.
+// Packages built on this one use it to report input errors from the luhn
+// functions they call as their own.
+func (e *ValidationError) WithFunc(fn string) *ValidationError {
+	c := *e
+	c.Func = fn
+	return &c
+}
+
+// NewValidationError builds a *ValidationError reporting that fn rejected
+// value with the given reason and sentinel err. For a failure at byte offset i
+// of value it fills in Index, Char, Rune and RuneIndex; pass i < 0 for checks
+// that do not concern a single character. It lets packages built on this one
+// report their own failures in the same form.
+func NewValidationError(fn string, reason Reason, err error, value string, i int) *ValidationError {
+	return newValidationError(fn, reason, err, value, i)
+}
+
+// newValidationError builds a *ValidationError for a failure at byte offset i
+// of value. Pass i < 0 for checks that do not concern a single character.
+func newValidationError[T text](fn string, reason Reason, err error, value T, i int) *ValidationError {
+	e := &ValidationError{Func: fn, Reason: reason, Index: -1, RuneIndex: -1, Err: err}
+	if i >= 0 && i < len(value) {
+		e.Index = i
+		e.Char = value[i]
+		e.Rune, _ = utf8.DecodeRuneInString(string(value[i:]))
+		e.RuneIndex = utf8.RuneCountInString(string(value[:i]))
+	}
+	return e
+}
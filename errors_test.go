@@ -0,0 +1,111 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestErrorsIs verifies that every validation failure matches its exported sentinel.
+func TestErrorsIs(t *testing.T) {
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"Generate empty", func() error { _, err := luhn.Generate("", false); return err }, luhn.ErrEmpty},
+		{"Generate spaces", func() error { _, err := luhn.Generate("1 2", false); return err }, luhn.ErrSpaces},
+		{"Generate negative", func() error { _, err := luhn.Generate("-1", false); return err }, luhn.ErrNegative},
+		{"Generate float", func() error { _, err := luhn.Generate("1.2", false); return err }, luhn.ErrFloat},
+		{"Generate non-numeric", func() error { _, err := luhn.Generate("1a", false); return err }, luhn.ErrNotNumeric},
+		{"Validate length 1", func() error { _, err := luhn.Validate("1"); return err }, luhn.ErrMinLength},
+		{"Random too large", func() error { _, err := luhn.Random("101"); return err }, luhn.ErrRandomMax},
+		{"Random too small", func() error { _, err := luhn.Random("1"); return err }, luhn.ErrRandomMin},
+		{"GenerateModN invalid n", func() error { _, err := luhn.GenerateModN("A", 37, false); return err }, luhn.ErrInvalidN},
+		{"GenerateModN invalid char", func() error { _, err := luhn.GenerateModN("A!", 36, false); return err }, luhn.ErrInvalidCharacter},
+		{"ValidateModN length 1", func() error { _, err := luhn.ValidateModN("A", 36); return err }, luhn.ErrMinLength},
+		{"ChecksumModN spaces", func() error { _, err := luhn.ChecksumModN(" A", 36); return err }, luhn.ErrSpaces},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want errors.Is(err, %v)", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidationErrorFields verifies the details carried by *ValidationError.
+func TestValidationErrorFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		call   func() error
+		fn     string
+		reason luhn.Reason
+		index  int
+		char   byte
+	}{
+		{"empty", func() error { _, err := luhn.Generate("", false); return err }, "Generate", luhn.ReasonEmpty, -1, 0},
+		{"spaces", func() error { _, err := luhn.Validate("12 3"); return err }, "Validate", luhn.ReasonSpaces, 2, ' '},
+		{"negative", func() error { _, err := luhn.Random("-5"); return err }, "Random", luhn.ReasonNegative, 0, '-'},
+		{"float", func() error { _, err := luhn.Generate("12.5", false); return err }, "Generate", luhn.ReasonFloat, 2, '.'},
+		{"non-numeric", func() error { _, err := luhn.Generate("123x", false); return err }, "Generate", luhn.ReasonNotNumeric, 3, 'x'},
+		{"too short", func() error { _, err := luhn.Validate("1"); return err }, "Validate", luhn.ReasonTooShort, -1, 0},
+		{"out of range", func() error { _, err := luhn.Random("101"); return err }, "Random", luhn.ReasonOutOfRange, -1, 0},
+		{"invalid n", func() error { _, err := luhn.ChecksumModN("A", 0); return err }, "ChecksumModN", luhn.ReasonInvalidN, -1, 0},
+		{"invalid char", func() error { _, err := luhn.ValidateModN("AB!C", 36); return err }, "ValidateModN", luhn.ReasonInvalidCharacter, 2, '!'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verr *luhn.ValidationError
+			if !errors.As(tt.call(), &verr) {
+				t.Fatal("expected *luhn.ValidationError")
+			}
+			if verr.Func != tt.fn {
+				t.Errorf("Func = %q, want %q", verr.Func, tt.fn)
+			}
+			if verr.Reason != tt.reason {
+				t.Errorf("Reason = %q, want %q", verr.Reason, tt.reason)
+			}
+			if verr.Index != tt.index {
+				t.Errorf("Index = %d, want %d", verr.Index, tt.index)
+			}
+			if verr.Char != tt.char {
+				t.Errorf("Char = %q, want %q", verr.Char, tt.char)
+			}
+		})
+	}
+}
+
+// TestNewValidationError tests the exported constructor and WithFunc.
+func TestNewValidationError(t *testing.T) {
+	e := luhn.NewValidationError("Parse", luhn.ReasonNotNumeric, luhn.ErrNotNumeric, "12é4", 2)
+	if e.Index != 2 || e.Char != 0xc3 || e.Rune != 'é' || e.RuneIndex != 2 {
+		t.Errorf("Index, Char, Rune, RuneIndex = %d, %q, %q, %d", e.Index, e.Char, e.Rune, e.RuneIndex)
+	}
+	if !errors.Is(e, luhn.ErrNotNumeric) {
+		t.Errorf("got %v, want ErrNotNumeric", e)
+	}
+
+	e = luhn.NewValidationError("Parse", luhn.ReasonLength, luhn.ErrEmpty, "", -1)
+	if e.Index != -1 || e.RuneIndex != -1 || e.Char != 0 {
+		t.Errorf("Index, RuneIndex, Char = %d, %d, %q, want -1, -1, 0", e.Index, e.RuneIndex, e.Char)
+	}
+
+	_, err := luhn.Generate("12a", false)
+	var verr *luhn.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatal("expected *luhn.ValidationError")
+	}
+	renamed := verr.WithFunc("Parse")
+	if renamed.Func != "Parse" || renamed.Index != 2 || !errors.Is(renamed, luhn.ErrNotNumeric) {
+		t.Errorf("WithFunc = %+v", renamed)
+	}
+	if verr.Func != "Generate" {
+		t.Errorf("WithFunc modified the original: Func = %q", verr.Func)
+	}
+}
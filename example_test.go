@@ -89,3 +89,105 @@ func ExampleChecksumModN() {
 	// 19
 	// J
 }
+
+func ExampleValidateWith() {
+	// Accept card numbers formatted with spaces or dashes.
+	valid, _ := luhn.ValidateWith("4111 1111-1111 1111", luhn.WithSeparators(" -"))
+	fmt.Println(valid)
+
+	// Output:
+	// true
+}
+
+func ExampleNewSeededGenerator() {
+	// A seeded generator produces the same numbers on every run.
+	g := luhn.NewSeededGenerator(1)
+	result, _ := g.Random(16)
+	fmt.Println(result)
+
+	// Output:
+	// 5227650536211152
+}
+
+func ExampleSuggest() {
+	// The last two digits of 79927398713 were swapped. Single-digit
+	// substitutions are ranked first, followed by transpositions.
+	suggestions, _ := luhn.Suggest("79927398731")
+	fmt.Println(suggestions[0].Value, suggestions[0].Kind, suggestions[0].Index)
+	for _, s := range suggestions {
+		if s.Kind == luhn.Transposition {
+			fmt.Println(s.Value, s.Kind, s.Index)
+		}
+	}
+
+	// Output:
+	// 59927398731 substitution 0
+	// 97927398731 transposition 0
+	// 79297398731 transposition 2
+	// 79927398713 transposition 9
+}
+
+func ExampleComplete() {
+	// Recover a smudged digit anywhere in the number.
+	result, _ := luhn.Complete("7992?398713")
+	fmt.Println(result)
+
+	// Output:
+	// 79927398713
+}
+
+func ExampleCompletions() {
+	// Enumerate every valid completion of a pattern with two unknown digits.
+	c, _ := luhn.Completions("12?4?")
+	for c.Next() {
+		fmt.Println(c.Value())
+	}
+
+	// Output:
+	// 12047
+	// 12146
+	// 12245
+	// 12344
+	// 12443
+	// 12542
+	// 12641
+	// 12740
+	// 12849
+	// 12948
+}
+
+func ExampleNewAlphabet() {
+	// Build a hexadecimal alphabet that renders check characters in lowercase.
+	hex, _ := luhn.NewAlphabet("0123456789abcdef", true)
+	result, _ := hex.Generate("ff", false)
+	fmt.Println(result)
+
+	valid, _ := hex.Validate("FF2")
+	fmt.Println(valid)
+
+	// Output:
+	// ff2
+	// true
+}
+
+func ExampleLookup() {
+	s, err := luhn.Lookup("luhn-mod-36")
+	if err != nil {
+		panic(err)
+	}
+	code, _ := s.Generate("HELLO")
+	fmt.Println(code)
+	// Output: HELLOJ
+}
+
+func ExampleValidateNPI() {
+	valid, _ := luhn.ValidateNPI("1234567893")
+	fmt.Println(valid)
+
+	// Validate omits the 80840 prefix and gives the wrong answer.
+	valid, _ = luhn.Validate("1234567893")
+	fmt.Println(valid)
+	// Output:
+	// true
+	// false
+}
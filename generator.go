@@ -0,0 +1,183 @@
+package luhn
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// defaultGenerator backs Random and draws from crypto/rand.
+var defaultGenerator = NewGenerator(nil)
+
+// Generator produces random Luhn-valid numbers from a configurable source of
+// entropy. A Generator is safe for concurrent use if its source is.
+type Generator struct {
+	r io.Reader
+}
+
+// NewGenerator returns a Generator that draws randomness from r. If r is nil,
+// crypto/rand.Reader is used.
+func NewGenerator(r io.Reader) *Generator {
+	if r == nil {
+		r = rand.Reader
+	}
+	return &Generator{r: r}
+}
+
+// NewSeededGenerator returns a Generator backed by a deterministic
+// pseudo-random source, producing the same sequence for the same seed. It is
+// intended for reproducible test fixtures and must not be used where
+// unpredictability matters. The returned Generator is not safe for
+// concurrent use.
+func NewSeededGenerator(seed uint64) *Generator {
+	return NewGenerator(&seededReader{state: seed})
+}
+
+// Random generates a random numeric string of length n with a valid Luhn
+// check digit. The first digit is never zero. Returns an error if n is out of
+// range [2, 100] or the entropy source fails.
+func (g *Generator) Random(n int) (string, error) {
+	return g.random("Generator.Random", n)
+}
+
+// random implements Generator.Random, reporting errors as coming from fn.
+func (g *Generator) random(fn string, n int) (string, error) {
+	if err := checkRandomLength(fn, n); err != nil {
+		return "", err
+	}
+
+	// Generate n-1 random digits (first digit 1-9, rest 0-9)
+	buf := make([]byte, n-1)
+	if err := g.fillDigits(buf, true); err != nil {
+		return "", err
+	}
+
+	// Append check digit via Generate
+	result, err := generate(fn, string(buf), false)
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// RandomWithPrefix generates a random numeric string of the given length that
+// begins with prefix (e.g. a card BIN or IMEI TAC) and ends with a valid Luhn
+// check digit. Only the positions between prefix and the check digit are
+// random. Returns an error if prefix fails input validation, length is out of
+// range [2, 100], or prefix leaves no room for the check digit.
+func (g *Generator) RandomWithPrefix(prefix string, length int) (string, error) {
+	return g.randomWithPrefix("Generator.RandomWithPrefix", prefix, length)
+}
+
+// randomWithPrefix implements Generator.RandomWithPrefix, reporting errors as
+// coming from fn.
+func (g *Generator) randomWithPrefix(fn, prefix string, length int) (string, error) {
+	if err := checkPrefix(fn, prefix, length); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, length-1)
+	copy(buf, prefix)
+	if err := g.fillDigits(buf[len(prefix):], false); err != nil {
+		return "", err
+	}
+	return string(append(buf, generateChecksum(buf))), nil
+}
+
+// Intn returns a uniformly distributed random int in [0, n) drawn from the
+// generator's source, for building other random values on the same source.
+// Returns ErrInvalidBound if n <= 0.
+func (g *Generator) Intn(n int) (int, error) {
+	if n <= 0 {
+		return 0, newValidationError("Generator.Intn", ReasonOutOfRange, ErrInvalidBound, "", -1)
+	}
+	s := sampler{g: g}
+	v, err := s.uniform(uint64(n))
+	return int(v), err
+}
+
+// checkRandomLength applies the length range checks shared by the random
+// functions.
+func checkRandomLength(fn string, n int) error {
+	if n > 100 {
+		return newValidationError(fn, ReasonOutOfRange, ErrRandomMax, "", -1)
+	}
+	if n < 2 {
+		return newValidationError(fn, ReasonOutOfRange, ErrRandomMin, "", -1)
+	}
+	return nil
+}
+
+// checkPrefix validates prefix and checks that prefix plus a check digit fits
+// within length.
+func checkPrefix(fn, prefix string, length int) error {
+	if err := validateInput(fn, prefix); err != nil {
+		return err
+	}
+	if err := checkRandomLength(fn, length); err != nil {
+		return err
+	}
+	if len(prefix) >= length {
+		return newValidationError(fn, ReasonTooLong, ErrPrefixTooLong, prefix, -1)
+	}
+	return nil
+}
+
+// fillDigits fills buf with uniformly distributed random digits. If nonZero
+// is true the first digit is drawn from 1-9. Digits are drawn from the source
+// one byte at a time by rejection sampling, so a seeded source always yields
+// the same digits.
+func (g *Generator) fillDigits(buf []byte, nonZero bool) error {
+	for i := 0; i < len(buf); {
+		// Read the remaining positions in one call and compact the accepted
+		// digits in place; each write lands at or before the byte being read.
+		chunk := buf[i:]
+		if _, err := io.ReadFull(g.r, chunk); err != nil {
+			return err
+		}
+		for _, c := range chunk {
+			if d, ok := sampleDigit(c, nonZero && i == 0); ok {
+				buf[i] = d
+				i++
+			}
+		}
+	}
+	return nil
+}
+
+// sampleDigit maps the random byte c to a digit, drawn from 1-9 if nonZero is
+// true and from 0-9 otherwise. It rejects bytes at or above the largest
+// multiple of the digit range below 256 (250 for 0-9, 243 for 1-9) so every
+// digit is equally likely.
+func sampleDigit(c byte, nonZero bool) (byte, bool) {
+	if nonZero {
+		return '1' + c%9, c < 243
+	}
+	return '0' + c%10, c < 250
+}
+
+// seededReader is a deterministic io.Reader based on SplitMix64. Its output is
+// fixed by this package, unlike math/rand, and the Generator turns bytes into
+// digits with its own sampler, so seeded fixtures stay stable across Go
+// releases.
+type seededReader struct {
+	state uint64
+	buf   [8]byte
+	n     int
+}
+
+func (s *seededReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if s.n == 0 {
+			s.state += 0x9e3779b97f4a7c15
+			z := s.state
+			z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+			z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+			binary.LittleEndian.PutUint64(s.buf[:], z^(z>>31))
+			s.n = len(s.buf)
+		}
+		p[i] = s.buf[len(s.buf)-s.n]
+		s.n--
+	}
+	return len(p), nil
+}
@@ -0,0 +1,220 @@
+package luhn_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestGeneratorRandomProperties tests Generator.Random output properties.
+func TestGeneratorRandomProperties(t *testing.T) {
+	g := luhn.NewGenerator(nil)
+	for _, n := range []int{2, 5, 16, 100} {
+		result, err := g.Random(n)
+		if err != nil {
+			t.Fatalf("Random(%d) error: %v", n, err)
+		}
+		if len(result) != n {
+			t.Errorf("len(Random(%d)) = %d", n, len(result))
+		}
+		if result[0] == '0' {
+			t.Errorf("Random(%d) = %q, first digit is zero", n, result)
+		}
+		if valid, err := luhn.Validate(result); err != nil || !valid {
+			t.Errorf("Random(%d) produced %q which fails Validate", n, result)
+		}
+	}
+}
+
+// TestSeededGeneratorDeterministic verifies that equal seeds produce equal sequences.
+func TestSeededGeneratorDeterministic(t *testing.T) {
+	a := luhn.NewSeededGenerator(42)
+	b := luhn.NewSeededGenerator(42)
+	c := luhn.NewSeededGenerator(43)
+
+	differs := false
+	for i := 0; i < 10; i++ {
+		x, err := a.Random(16)
+		if err != nil {
+			t.Fatalf("Random error: %v", err)
+		}
+		y, _ := b.Random(16)
+		z, _ := c.Random(16)
+		if x != y {
+			t.Fatalf("iteration %d: seeded generators diverged: %q != %q", i, x, y)
+		}
+		if x != z {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Error("different seeds produced identical sequences")
+	}
+}
+
+// TestSeededGeneratorFixture pins the seeded output so fixtures stay stable across releases.
+func TestSeededGeneratorFixture(t *testing.T) {
+	got, err := luhn.NewSeededGenerator(1).Random(16)
+	if err != nil {
+		t.Fatalf("Random error: %v", err)
+	}
+	if want := "5227650536211152"; got != want {
+		t.Errorf("NewSeededGenerator(1).Random(16) = %q, want %q", got, want)
+	}
+}
+
+// TestGeneratorSampling pins how source bytes become digits: bytes that would
+// bias the result are skipped, and the first digit is never zero.
+func TestGeneratorSampling(t *testing.T) {
+	// 255 is rejected for the first digit, 0 maps to 1, 249 to 9, 250 is
+	// rejected, and 13 maps to 3.
+	g := luhn.NewGenerator(bytes.NewReader([]byte{255, 0, 249, 250, 13}))
+	got, err := g.Random(4)
+	if err != nil {
+		t.Fatalf("Random error: %v", err)
+	}
+	if want := "1933"; got != want {
+		t.Errorf("Random(4) = %q, want %q", got, want)
+	}
+}
+
+// TestGeneratorReaderError verifies that entropy source failures are returned.
+func TestGeneratorReaderError(t *testing.T) {
+	g := luhn.NewGenerator(bytes.NewReader(nil))
+	if _, err := g.Random(16); err == nil {
+		t.Fatal("expected error from exhausted reader, got nil")
+	}
+}
+
+// TestGeneratorIntn tests the range, determinism and errors of Intn.
+func TestGeneratorIntn(t *testing.T) {
+	g, h := luhn.NewSeededGenerator(3), luhn.NewSeededGenerator(3)
+	seen := map[int]bool{}
+	for i := 0; i < 200; i++ {
+		v, err := g.Intn(5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v < 0 || v >= 5 {
+			t.Fatalf("Intn(5) = %d, out of range", v)
+		}
+		if w, _ := h.Intn(5); w != v {
+			t.Fatalf("seeded Intn differs: %d and %d", v, w)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("Intn(5) produced only %v", seen)
+	}
+
+	// Each draw reads 8 bytes, so 8 zero bytes give exactly one value.
+	z := luhn.NewGenerator(bytes.NewReader(make([]byte, 8)))
+	if v, err := z.Intn(7); v != 0 || err != nil {
+		t.Errorf("Intn(7) = %d, %v, want 0", v, err)
+	}
+	if _, err := z.Intn(7); err == nil {
+		t.Error("expected error from exhausted reader, got nil")
+	}
+
+	for _, n := range []int{0, -1} {
+		_, err := g.Intn(n)
+		var ve *luhn.ValidationError
+		if !errors.As(err, &ve) || !errors.Is(err, luhn.ErrInvalidBound) || ve.Func != "Generator.Intn" {
+			t.Errorf("Intn(%d) = %v, want ErrInvalidBound", n, err)
+		}
+	}
+}
+
+// TestGeneratorRandomErrors tests the length range checks.
+func TestGeneratorRandomErrors(t *testing.T) {
+	g := luhn.NewGenerator(nil)
+	tests := []struct {
+		n    int
+		want error
+	}{
+		{-1, luhn.ErrRandomMin},
+		{0, luhn.ErrRandomMin},
+		{1, luhn.ErrRandomMin},
+		{101, luhn.ErrRandomMax},
+	}
+
+	for _, tt := range tests {
+		_, err := g.Random(tt.n)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Random(%d): got %v, want %v", tt.n, err, tt.want)
+		}
+	}
+}
+
+// TestRandomWithPrefix tests that RandomWithPrefix keeps the prefix and produces valid numbers.
+func TestRandomWithPrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		length int
+	}{
+		{"4", 16},
+		{"411111", 16},
+		{"35209900", 15},
+		{"0", 2},
+		{"123456789012345", 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			result, err := luhn.RandomWithPrefix(tt.prefix, tt.length)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != tt.length {
+				t.Errorf("len = %d, want %d", len(result), tt.length)
+			}
+			if !strings.HasPrefix(result, tt.prefix) {
+				t.Errorf("%q does not start with %q", result, tt.prefix)
+			}
+			if valid, err := luhn.Validate(result); err != nil || !valid {
+				t.Errorf("%q fails Validate", result)
+			}
+		})
+	}
+}
+
+// TestRandomWithPrefixDeterministic verifies that the seeded Generator fills only the random positions.
+func TestRandomWithPrefixDeterministic(t *testing.T) {
+	a, err := luhn.NewSeededGenerator(7).RandomWithPrefix("411111", 16)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := luhn.NewSeededGenerator(7).RandomWithPrefix("411111", 16)
+	if a != b {
+		t.Errorf("seeded generators diverged: %q != %q", a, b)
+	}
+}
+
+// TestRandomWithPrefixErrors tests prefix and length validation.
+func TestRandomWithPrefixErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		length int
+		want   error
+	}{
+		{"empty prefix", "", 16, luhn.ErrEmpty},
+		{"non-numeric prefix", "4a", 16, luhn.ErrNotNumeric},
+		{"length too small", "4", 1, luhn.ErrRandomMin},
+		{"length too large", "4", 101, luhn.ErrRandomMax},
+		{"no room for check digit", "4111", 4, luhn.ErrPrefixTooLong},
+		{"prefix longer than length", "41111", 4, luhn.ErrPrefixTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := luhn.RandomWithPrefix(tt.prefix, tt.length)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
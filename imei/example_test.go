@@ -0,0 +1,16 @@
+package imei_test
+
+import (
+	"fmt"
+
+	"github.com/jrrembert/go-luhn/imei"
+)
+
+func ExampleParse() {
+	i, err := imei.Parse("490154203237518")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(i.TAC, i.ReportingBody, i.Serial, i.CheckDigit)
+	// Output: 49015420 49 323751 8
+}
@@ -0,0 +1,120 @@
+// Package imei parses and generates the IMEI (International Mobile Equipment
+// Identity) numbers of mobile devices, on top of the Luhn functions of
+// package luhn.
+//
+// An IMEI has 15 digits: an 8-digit Type Allocation Code (TAC), whose first
+// two digits identify the reporting body, a 6-digit serial number, and a Luhn
+// check digit. An IMEISV has 16 digits: the TAC, the serial number, and a
+// 2-digit software version number instead of the check digit.
+package imei
+
+import (
+	"errors"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// Lengths of the IMEI fields.
+const (
+	tacLength    = 8
+	rbiLength    = 2
+	serialLength = 6
+	bodyLength   = tacLength + serialLength
+	imeiLength   = bodyLength + 1
+	imeisvLength = bodyLength + 2
+)
+
+// IMEI length and check digit failures, reported in a *luhn.ValidationError
+// with reason luhn.ReasonLength or luhn.ReasonCheckDigit. Malformed input
+// fails with the luhn input errors instead, such as luhn.ErrNotNumeric.
+var (
+	ErrInvalidLength     = errors.New("IMEI must have 15 digits, or 16 for an IMEISV")
+	ErrBodyLength        = errors.New("IMEI body must have 14 digits")
+	ErrInvalidCheckDigit = errors.New("invalid check digit")
+)
+
+// IMEI is a parsed IMEI or IMEISV.
+type IMEI struct {
+	// TAC is the 8-digit Type Allocation Code identifying the device model.
+	TAC string
+	// ReportingBody is the 2-digit Reporting Body Identifier, the start of
+	// the TAC.
+	ReportingBody string
+	// Serial is the 6-digit serial number.
+	Serial string
+	// CheckDigit is the Luhn check digit of an IMEI, or -1 for an IMEISV.
+	CheckDigit int
+	// SoftwareVersion is the 2-digit software version number of an IMEISV,
+	// or "" for an IMEI.
+	SoftwareVersion string
+}
+
+// Parse parses a 15-digit IMEI, whose Luhn check digit must be valid, or a
+// 16-digit IMEISV. Input errors are those of luhn.Validate, reported as
+// coming from Parse.
+func Parse(s string) (IMEI, error) {
+	// Validate applies the input checks to both forms; its result only
+	// matters for an IMEI.
+	valid, err := luhn.Validate(s)
+	if err != nil {
+		return IMEI{}, err.(*luhn.ValidationError).WithFunc("Parse")
+	}
+	if len(s) != imeiLength && len(s) != imeisvLength {
+		return IMEI{}, luhn.NewValidationError("Parse", luhn.ReasonLength, ErrInvalidLength, "", -1)
+	}
+
+	i := IMEI{
+		TAC:           s[:tacLength],
+		ReportingBody: s[:rbiLength],
+		Serial:        s[tacLength:bodyLength],
+		CheckDigit:    -1,
+	}
+	if len(s) == imeisvLength {
+		i.SoftwareVersion = s[bodyLength:]
+		return i, nil
+	}
+	if !valid {
+		return IMEI{}, luhn.NewValidationError("Parse", luhn.ReasonCheckDigit, ErrInvalidCheckDigit, "", -1)
+	}
+	i.CheckDigit = int(s[bodyLength] - '0')
+	return i, nil
+}
+
+// IsSV reports whether i was parsed from an IMEISV.
+func (i IMEI) IsSV() bool {
+	return i.SoftwareVersion != ""
+}
+
+// IMEI returns the 15-digit IMEI. The check digit is computed from the TAC
+// and serial number rather than taken from CheckDigit, so it is also correct
+// for an IMEISV. Returns "" if the TAC and serial number are not digits, as
+// in the zero IMEI.
+func (i IMEI) IMEI() string {
+	s, err := luhn.Generate(i.TAC+i.Serial, false)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// String returns the IMEI or IMEISV in its original 15- or 16-digit form.
+func (i IMEI) String() string {
+	if i.IsSV() {
+		return i.TAC + i.Serial + i.SoftwareVersion
+	}
+	return i.IMEI()
+}
+
+// Generate appends the Luhn check digit to a 14-digit IMEI body (TAC and
+// serial number). Input errors are those of luhn.Generate, reported as coming
+// from Generate.
+func Generate(body string) (string, error) {
+	s, err := luhn.Generate(body, false)
+	if err != nil {
+		return "", err.(*luhn.ValidationError).WithFunc("Generate")
+	}
+	if len(body) != bodyLength {
+		return "", luhn.NewValidationError("Generate", luhn.ReasonLength, ErrBodyLength, "", -1)
+	}
+	return s, nil
+}
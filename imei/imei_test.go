@@ -0,0 +1,126 @@
+package imei_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+	"github.com/jrrembert/go-luhn/imei"
+)
+
+// TestParse tests parsing an IMEI.
+func TestParse(t *testing.T) {
+	got, err := imei.Parse("490154203237518")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := imei.IMEI{
+		TAC:           "49015420",
+		ReportingBody: "49",
+		Serial:        "323751",
+		CheckDigit:    8,
+	}
+	if got != want {
+		t.Errorf("Parse = %+v, want %+v", got, want)
+	}
+	if got.IsSV() {
+		t.Error("IsSV = true for an IMEI")
+	}
+	if s := got.String(); s != "490154203237518" {
+		t.Errorf("String = %q", s)
+	}
+	if s := got.IMEI(); s != "490154203237518" {
+		t.Errorf("IMEI = %q", s)
+	}
+}
+
+// TestParse_IMEISV tests parsing an IMEISV, which has no check digit.
+func TestParse_IMEISV(t *testing.T) {
+	got, err := imei.Parse("4901542032375123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := imei.IMEI{
+		TAC:             "49015420",
+		ReportingBody:   "49",
+		Serial:          "323751",
+		CheckDigit:      -1,
+		SoftwareVersion: "23",
+	}
+	if got != want {
+		t.Errorf("Parse = %+v, want %+v", got, want)
+	}
+	if !got.IsSV() {
+		t.Error("IsSV = false for an IMEISV")
+	}
+	if s := got.String(); s != "4901542032375123" {
+		t.Errorf("String = %q", s)
+	}
+	if s := got.IMEI(); s != "490154203237518" {
+		t.Errorf("IMEI = %q, want the IMEI with its check digit", s)
+	}
+}
+
+// TestParse_Errors tests the rejected inputs.
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"empty", "", luhn.ErrEmpty},
+		{"spaces", "49015420 3237518", luhn.ErrSpaces},
+		{"not numeric", "49015420323751A", luhn.ErrNotNumeric},
+		{"check digit", "490154203237519", imei.ErrInvalidCheckDigit},
+		{"too short", "79927398713", imei.ErrInvalidLength},
+		{"too long", "49015420323751234", imei.ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := imei.Parse(tt.input)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if ve.Func != "Parse" {
+				t.Errorf("Func = %q, want Parse", ve.Func)
+			}
+		})
+	}
+}
+
+// TestGenerate tests appending the check digit to a body.
+func TestGenerate(t *testing.T) {
+	got, err := imei.Generate("49015420323751")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "490154203237518" {
+		t.Errorf("Generate = %q, want %q", got, "490154203237518")
+	}
+	if _, err := imei.Parse(got); err != nil {
+		t.Errorf("Parse(Generate()) = %v", err)
+	}
+
+	if _, err := imei.Generate("4901542032375"); !errors.Is(err, imei.ErrBodyLength) {
+		t.Errorf("got %v, want ErrBodyLength", err)
+	}
+	_, err = imei.Generate("4901542032375x")
+	var ve *luhn.ValidationError
+	if !errors.As(err, &ve) || !errors.Is(err, luhn.ErrNotNumeric) || ve.Func != "Generate" {
+		t.Errorf("got %v, want ErrNotNumeric from Generate", err)
+	}
+}
+
+// TestIMEI_IMEI tests that the check digit is computed rather than copied
+// from hand-built values.
+func TestIMEI_IMEI(t *testing.T) {
+	i := imei.IMEI{TAC: "49015420", Serial: "323751", CheckDigit: -1}
+	if s := i.IMEI(); s != "490154203237518" {
+		t.Errorf("IMEI() = %q, want %q", s, "490154203237518")
+	}
+	if s := (imei.IMEI{}).IMEI(); s != "" {
+		t.Errorf("zero IMEI() = %q, want empty", s)
+	}
+}
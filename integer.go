@@ -0,0 +1,126 @@
+package luhn
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// doubledDigit maps a digit to the sum of the digits of its double.
+var doubledDigit = [10]int{0, 2, 4, 6, 8, 1, 3, 5, 7, 9}
+
+// digitsPerWord is the number of decimal digits extracted from a big.Int per
+// division by wordBase. 10^19 is the largest power of ten that fits in a uint64.
+const digitsPerWord = 19
+
+var wordBase = new(big.Int).SetUint64(10_000_000_000_000_000_000)
+
+// sumDigits adds the Luhn contributions of the decimal digits of v, processed
+// right to left, to sum. If width > 0 exactly width digits are consumed
+// (zero-padding v on the left), otherwise digits are consumed until v is
+// exhausted. It returns the new sum and doubling state.
+func sumDigits(v uint64, width int, sum int, shouldDouble bool) (int, bool) {
+	for i := 0; width <= 0 || i < width; i++ {
+		digit := int(v % 10)
+		if shouldDouble {
+			sum += doubledDigit[digit]
+		} else {
+			sum += digit
+		}
+		shouldDouble = !shouldDouble
+		v /= 10
+		if width <= 0 && v == 0 {
+			break
+		}
+	}
+	return sum, shouldDouble
+}
+
+// CheckDigitUint64 returns the Luhn check digit of the decimal representation of v.
+func CheckDigitUint64(v uint64) int {
+	sum, _ := sumDigits(v, 0, 0, true)
+	return (10 - sum%10) % 10
+}
+
+// GenerateUint64 returns v with its Luhn check digit appended, i.e. v*10 + CheckDigitUint64(v).
+// Returns ErrOverflow if the result does not fit in a uint64.
+func GenerateUint64(v uint64) (uint64, error) {
+	hi, lo := bits.Mul64(v, 10)
+	result, carry := bits.Add64(lo, uint64(CheckDigitUint64(v)), 0)
+	if hi != 0 || carry != 0 {
+		return 0, newValidationError("GenerateUint64", ReasonOutOfRange, ErrOverflow, "", -1)
+	}
+	return result, nil
+}
+
+// ValidateUint64 determines whether the last decimal digit of v is a valid Luhn
+// check digit for the remaining digits. Like Validate, it returns
+// ErrMinLength if v has a single digit.
+func ValidateUint64(v uint64) (bool, error) {
+	if v < 10 {
+		return false, newValidationError("ValidateUint64", ReasonTooShort, ErrMinLength, "", -1)
+	}
+	return CheckDigitUint64(v/10) == int(v%10), nil
+}
+
+// checkBig validates a *big.Int argument for the functions named fn.
+func checkBig(fn string, v *big.Int) error {
+	if v == nil {
+		return newValidationError(fn, ReasonEmpty, ErrEmpty, "", -1)
+	}
+	if v.Sign() < 0 {
+		return newValidationError(fn, ReasonNegative, ErrNegative, "", -1)
+	}
+	return nil
+}
+
+// checkDigitBig computes the Luhn check digit of a non-negative v.
+func checkDigitBig(v *big.Int) int {
+	if v.IsUint64() {
+		return CheckDigitUint64(v.Uint64())
+	}
+
+	sum, shouldDouble := 0, true
+	q, r := new(big.Int).Set(v), new(big.Int)
+	for !q.IsUint64() {
+		q.QuoRem(q, wordBase, r)
+		sum, shouldDouble = sumDigits(r.Uint64(), digitsPerWord, sum, shouldDouble)
+	}
+	sum, _ = sumDigits(q.Uint64(), 0, sum, shouldDouble)
+	return (10 - sum%10) % 10
+}
+
+// CheckDigitBig returns the Luhn check digit of the decimal representation of v.
+// Returns an error if v is nil or negative.
+func CheckDigitBig(v *big.Int) (int, error) {
+	if err := checkBig("CheckDigitBig", v); err != nil {
+		return 0, err
+	}
+	return checkDigitBig(v), nil
+}
+
+// GenerateBig returns a new big.Int holding v with its Luhn check digit appended.
+// Returns an error if v is nil or negative.
+func GenerateBig(v *big.Int) (*big.Int, error) {
+	if err := checkBig("GenerateBig", v); err != nil {
+		return nil, err
+	}
+	result := new(big.Int).Mul(v, big.NewInt(10))
+	return result.Add(result, big.NewInt(int64(checkDigitBig(v)))), nil
+}
+
+// ValidateBig determines whether the last decimal digit of v is a valid Luhn
+// check digit for the remaining digits. Returns an error if v is nil,
+// negative, or has a single digit.
+func ValidateBig(v *big.Int) (bool, error) {
+	if err := checkBig("ValidateBig", v); err != nil {
+		return false, err
+	}
+	if v.IsUint64() {
+		if v.Uint64() < 10 {
+			return false, newValidationError("ValidateBig", ReasonTooShort, ErrMinLength, "", -1)
+		}
+		return ValidateUint64(v.Uint64())
+	}
+	payload, check := new(big.Int).QuoRem(v, big.NewInt(10), new(big.Int))
+	return checkDigitBig(payload) == int(check.Int64()), nil
+}
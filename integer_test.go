@@ -0,0 +1,145 @@
+package luhn_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+var integerInputs = []uint64{
+	0, 1, 9, 12, 123, 7992739871, 411111111111111,
+	math.MaxUint32, math.MaxUint64 / 10, math.MaxUint64,
+}
+
+// TestUint64MatchesString verifies that the uint64 functions agree with Generate and Validate.
+func TestUint64MatchesString(t *testing.T) {
+	for _, v := range integerInputs {
+		s := strconv.FormatUint(v, 10)
+		t.Run(s, func(t *testing.T) {
+			want, err := luhn.Generate(s, true)
+			if err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+			if got := luhn.CheckDigitUint64(v); strconv.Itoa(got) != want {
+				t.Errorf("CheckDigitUint64(%d) = %d, want %s", v, got, want)
+			}
+
+			if v >= 10 {
+				wantValid, err := luhn.Validate(s)
+				if err != nil {
+					t.Fatalf("Validate error: %v", err)
+				}
+				gotValid, err := luhn.ValidateUint64(v)
+				if err != nil {
+					t.Fatalf("ValidateUint64 error: %v", err)
+				}
+				if gotValid != wantValid {
+					t.Errorf("ValidateUint64(%d) = %v, want %v", v, gotValid, wantValid)
+				}
+			}
+
+			if v <= math.MaxUint64/100 {
+				full, err := luhn.GenerateUint64(v)
+				if err != nil {
+					t.Fatalf("GenerateUint64 error: %v", err)
+				}
+				if wantFull, _ := strconv.ParseUint(s+want, 10, 64); full != wantFull {
+					t.Errorf("GenerateUint64(%d) = %d, want %s", v, full, s+want)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateUint64Overflow verifies that GenerateUint64 reports overflow.
+func TestGenerateUint64Overflow(t *testing.T) {
+	// 1844674407370955161 * 10 + 5 == MaxUint64, so any larger check digit overflows.
+	if _, err := luhn.GenerateUint64(math.MaxUint64); !errors.Is(err, luhn.ErrOverflow) {
+		t.Errorf("GenerateUint64(MaxUint64): got %v, want %v", err, luhn.ErrOverflow)
+	}
+
+	v := uint64(math.MaxUint64 / 10)
+	_, err := luhn.GenerateUint64(v)
+	if luhn.CheckDigitUint64(v) > 5 {
+		if !errors.Is(err, luhn.ErrOverflow) {
+			t.Errorf("GenerateUint64(%d): got %v, want %v", v, err, luhn.ErrOverflow)
+		}
+	} else if err != nil {
+		t.Errorf("GenerateUint64(%d): unexpected error %v", v, err)
+	}
+}
+
+// TestValidateUint64SingleDigit verifies the Validate-specific length check.
+func TestValidateUint64SingleDigit(t *testing.T) {
+	if _, err := luhn.ValidateUint64(7); !errors.Is(err, luhn.ErrMinLength) {
+		t.Errorf("got %v, want %v", err, luhn.ErrMinLength)
+	}
+}
+
+// TestBigMatchesString verifies that the big.Int functions agree with Generate and Validate,
+// including values that span several 19-digit words.
+func TestBigMatchesString(t *testing.T) {
+	inputs := []string{
+		"0", "18", "7992739871", "18446744073709551615",
+		"18446744073709551616",
+		"10000000000000000000000000000000000000",
+		"1234567890123456789012345678901234567890123456789",
+		strings.Repeat("9", 100),
+	}
+
+	for _, s := range inputs {
+		t.Run(s, func(t *testing.T) {
+			v, _ := new(big.Int).SetString(s, 10)
+
+			want, err := luhn.Generate(s, false)
+			if err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+			got, err := luhn.GenerateBig(v)
+			if err != nil {
+				t.Fatalf("GenerateBig error: %v", err)
+			}
+			// Compare numerically: leading zeros in the string form are not representable.
+			if wantInt, _ := new(big.Int).SetString(want, 10); got.Cmp(wantInt) != 0 {
+				t.Errorf("GenerateBig(%s) = %s, want %s", s, got, want)
+			}
+
+			digit, err := luhn.CheckDigitBig(v)
+			if err != nil {
+				t.Fatalf("CheckDigitBig error: %v", err)
+			}
+			if strconv.Itoa(digit) != want[len(want)-1:] {
+				t.Errorf("CheckDigitBig(%s) = %d, want %s", s, digit, want[len(want)-1:])
+			}
+
+			if len(s) > 1 {
+				wantValid, _ := luhn.Validate(s)
+				gotValid, err := luhn.ValidateBig(v)
+				if err != nil {
+					t.Fatalf("ValidateBig error: %v", err)
+				}
+				if gotValid != wantValid {
+					t.Errorf("ValidateBig(%s) = %v, want %v", s, gotValid, wantValid)
+				}
+			}
+		})
+	}
+}
+
+// TestBigErrors tests the big.Int argument checks.
+func TestBigErrors(t *testing.T) {
+	if _, err := luhn.GenerateBig(nil); !errors.Is(err, luhn.ErrEmpty) {
+		t.Errorf("GenerateBig(nil): got %v, want %v", err, luhn.ErrEmpty)
+	}
+	if _, err := luhn.CheckDigitBig(big.NewInt(-5)); !errors.Is(err, luhn.ErrNegative) {
+		t.Errorf("CheckDigitBig(-5): got %v, want %v", err, luhn.ErrNegative)
+	}
+	if _, err := luhn.ValidateBig(big.NewInt(5)); !errors.Is(err, luhn.ErrMinLength) {
+		t.Errorf("ValidateBig(5): got %v, want %v", err, luhn.ErrMinLength)
+	}
+}
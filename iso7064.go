@@ -0,0 +1,230 @@
+package luhn
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ISO7064 identifies a check character system from ISO/IEC 7064. Each system
+// implements Scheme and is registered under a name such as "iso7064-mod-11-2"
+// or "iso7064-mod-37-36".
+//
+// The pure systems MOD 97-10, MOD 661-26 and MOD 1271-36 produce two check
+// characters. MOD 11-2 and MOD 37-2 produce one, using the supplementary
+// check characters 'X' (for 10) and '*' (for 36) respectively. The hybrid
+// systems MOD 11,10 and MOD 37,36 produce one check character from the input
+// alphabet. Letters are accepted in either case; check characters are
+// rendered in uppercase.
+type ISO7064 int
+
+// ISO/IEC 7064 systems.
+const (
+	// ISO7064Mod11_2 is MOD 11-2 over the digits 0-9, e.g. for ORCID and ISNI.
+	ISO7064Mod11_2 ISO7064 = iota + 1
+	// ISO7064Mod37_2 is MOD 37-2 over the characters 0-9A-Z.
+	ISO7064Mod37_2
+	// ISO7064Mod97_10 is MOD 97-10 over the digits 0-9, the basis of the
+	// IBAN check digits.
+	ISO7064Mod97_10
+	// ISO7064Mod661_26 is MOD 661-26 over the letters A-Z.
+	ISO7064Mod661_26
+	// ISO7064Mod1271_36 is MOD 1271-36 over the characters 0-9A-Z.
+	ISO7064Mod1271_36
+	// ISO7064Mod11_10 is the hybrid MOD 11,10 over the digits 0-9.
+	ISO7064Mod11_10
+	// ISO7064Mod37_36 is the hybrid MOD 37,36 over the characters 0-9A-Z.
+	ISO7064Mod37_36
+)
+
+// iso7064System holds the parameters of an ISO/IEC 7064 system.
+type iso7064System struct {
+	name     string
+	key      string
+	modulus  int
+	radix    int
+	hybrid   bool
+	checkLen int
+	input    *Alphabet
+	check    *Alphabet
+}
+
+var iso7064Systems = [...]iso7064System{
+	ISO7064Mod11_2: {
+		name: "ISO 7064 MOD 11-2", key: "iso7064-mod-11-2", modulus: 11, radix: 2, checkLen: 1,
+		input: codePointAlphabets[10], check: mustAlphabet("0123456789X", true),
+	},
+	ISO7064Mod37_2: {
+		name: "ISO 7064 MOD 37-2", key: "iso7064-mod-37-2", modulus: 37, radix: 2, checkLen: 1,
+		input: codePointAlphabets[36], check: mustAlphabet(codePoints+"*", true),
+	},
+	ISO7064Mod97_10: {
+		name: "ISO 7064 MOD 97-10", key: "iso7064-mod-97-10", modulus: 97, radix: 10, checkLen: 2,
+		input: codePointAlphabets[10], check: codePointAlphabets[10],
+	},
+	ISO7064Mod661_26: {
+		name: "ISO 7064 MOD 661-26", key: "iso7064-mod-661-26", modulus: 661, radix: 26, checkLen: 2,
+		input: mustAlphabet(codePoints[10:], true), check: mustAlphabet(codePoints[10:], true),
+	},
+	ISO7064Mod1271_36: {
+		name: "ISO 7064 MOD 1271-36", key: "iso7064-mod-1271-36", modulus: 1271, radix: 36, checkLen: 2,
+		input: codePointAlphabets[36], check: codePointAlphabets[36],
+	},
+	ISO7064Mod11_10: {
+		name: "ISO 7064 MOD 11,10", key: "iso7064-mod-11-10", modulus: 10, hybrid: true, checkLen: 1,
+		input: codePointAlphabets[10], check: codePointAlphabets[10],
+	},
+	ISO7064Mod37_36: {
+		name: "ISO 7064 MOD 37,36", key: "iso7064-mod-37-36", modulus: 36, hybrid: true, checkLen: 1,
+		input: codePointAlphabets[36], check: codePointAlphabets[36],
+	},
+}
+
+// system returns the parameters of s, or an ErrUnknownScheme error reported
+// as coming from fn if s is not a defined system.
+func (s ISO7064) system(fn string) (*iso7064System, error) {
+	if s < ISO7064Mod11_2 || s > ISO7064Mod37_36 {
+		return nil, newValidationError(fn, ReasonUnknownScheme, ErrUnknownScheme, "", -1)
+	}
+	return &iso7064Systems[s], nil
+}
+
+// String returns the name of the system, e.g. "ISO 7064 MOD 11-2".
+func (s ISO7064) String() string {
+	if sys, err := s.system(""); err == nil {
+		return sys.name
+	}
+	return "ISO7064(" + strconv.Itoa(int(s)) + ")"
+}
+
+// checksum returns the check value of a payload that has passed validation.
+// It is in [0, modulus) and is rendered by render.
+func (sys *iso7064System) checksum(payload string) int {
+	if sys.hybrid {
+		m := sys.modulus
+		p := m
+		for _, r := range payload {
+			s := (p + sys.input.IndexRune(r)) % m
+			if s == 0 {
+				s = m
+			}
+			p = s * 2 % (m + 1)
+		}
+		return (m + 1 - p) % m
+	}
+
+	p := 0
+	for _, r := range payload {
+		p = (p + sys.input.IndexRune(r)) * sys.radix % sys.modulus
+	}
+	if sys.checkLen == 2 {
+		p = p * sys.radix % sys.modulus
+	}
+	return (sys.modulus + 1 - p) % sys.modulus
+}
+
+// render returns the check characters for the check value c.
+func (sys *iso7064System) render(c int) string {
+	if sys.checkLen == 2 {
+		return string([]rune{sys.check.runes[c/sys.radix], sys.check.runes[c%sys.radix]})
+	}
+	return string(sys.check.runes[c])
+}
+
+// GenerateISO7064 computes the check characters of value using the ISO/IEC
+// 7064 system s and appends them to value. If checksumOnly is true, only the
+// check characters are returned.
+func GenerateISO7064(value string, s ISO7064, checksumOnly bool) (string, error) {
+	return s.generate("GenerateISO7064", value, checksumOnly)
+}
+
+// generate implements GenerateISO7064, reporting errors as coming from fn.
+func (s ISO7064) generate(fn, value string, checksumOnly bool) (string, error) {
+	sys, err := s.system(fn)
+	if err != nil {
+		return "", err
+	}
+	if _, err := sys.input.validateInput(fn, value); err != nil {
+		return "", err
+	}
+
+	check := sys.render(sys.checksum(value))
+	if checksumOnly {
+		return check, nil
+	}
+	return value + check, nil
+}
+
+// ValidateISO7064 determines whether value ends with valid check characters
+// for the ISO/IEC 7064 system s. value must be longer than the check
+// characters: at least 2 characters for systems with one check character,
+// which otherwise fail with ErrMinLength, and 3 for systems with two, which
+// otherwise fail with ErrCheckLength.
+func ValidateISO7064(value string, s ISO7064) (bool, error) {
+	return s.validate("ValidateISO7064", value)
+}
+
+// validate implements ValidateISO7064, reporting errors as coming from fn.
+func (s ISO7064) validate(fn, value string) (bool, error) {
+	sys, err := s.system(fn)
+	if err != nil {
+		return false, err
+	}
+	if value == "" {
+		return false, newValidationError(fn, ReasonEmpty, ErrEmpty, value, -1)
+	}
+	if i := indexByte(value, ' '); i >= 0 {
+		return false, newValidationError(fn, ReasonSpaces, ErrSpaces, value, i)
+	}
+	if utf8.RuneCountInString(value) <= sys.checkLen {
+		if sys.checkLen == 1 {
+			return false, newValidationError(fn, ReasonTooShort, ErrMinLength, value, -1)
+		}
+		return false, newValidationError(fn, ReasonTooShort, ErrCheckLength, value, -1)
+	}
+
+	split := len(value)
+	for i := 0; i < sys.checkLen; i++ {
+		_, size := utf8.DecodeLastRuneInString(value[:split])
+		split -= size
+	}
+	if _, err := sys.input.validateInput(fn, value[:split]); err != nil {
+		return false, err
+	}
+	got := 0
+	for i, r := range value[split:] {
+		idx := sys.check.IndexRune(r)
+		if idx < 0 {
+			return false, newValidationError(fn, ReasonInvalidCharacter, ErrInvalidCharacter, value, split+i)
+		}
+		got = got*sys.radix + idx
+	}
+	// The check characters of the two-character systems can encode values
+	// at or above the modulus, which are never valid.
+	return subtle.ConstantTimeEq(int32(sys.checksum(value[:split])), int32(got)) == 1, nil
+}
+
+// Compute returns the check characters for value.
+func (s ISO7064) Compute(value string) (string, error) {
+	return s.generate("Scheme.Compute", value, true)
+}
+
+// Generate returns value followed by its check characters.
+func (s ISO7064) Generate(value string) (string, error) {
+	return s.generate("Scheme.Generate", value, false)
+}
+
+// Validate reports whether value ends with valid check characters.
+func (s ISO7064) Validate(value string) (bool, error) {
+	return s.validate("Scheme.Validate", value)
+}
+
+// Alphabet returns the characters accepted in the payload, or nil if s is not
+// a defined system.
+func (s ISO7064) Alphabet() *Alphabet {
+	sys, err := s.system("")
+	if err != nil {
+		return nil
+	}
+	return sys.input
+}
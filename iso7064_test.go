@@ -0,0 +1,173 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestGenerateISO7064 tests each system against known check characters,
+// mostly from the examples in the standard and ORCID documentation.
+func TestGenerateISO7064(t *testing.T) {
+	tests := []struct {
+		system luhn.ISO7064
+		input  string
+		want   string
+	}{
+		{luhn.ISO7064Mod11_2, "0794", "0"},
+		{luhn.ISO7064Mod11_2, "000000021825009", "7"},
+		{luhn.ISO7064Mod11_2, "000000021694233", "X"},
+		{luhn.ISO7064Mod37_2, "G123498654321", "H"},
+		{luhn.ISO7064Mod97_10, "794", "44"},
+		{luhn.ISO7064Mod661_26, "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "HK"},
+		{luhn.ISO7064Mod1271_36, "ISO79", "3W"},
+		{luhn.ISO7064Mod11_10, "0794", "5"},
+		{luhn.ISO7064Mod37_36, "A12425GABC1234002", "M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system.String()+" "+tt.input, func(t *testing.T) {
+			got, err := luhn.GenerateISO7064(tt.input, tt.system, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateISO7064(%q, true) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			full, err := luhn.GenerateISO7064(tt.input, tt.system, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if full != tt.input+tt.want {
+				t.Errorf("GenerateISO7064(%q) = %q, want %q", tt.input, full, tt.input+tt.want)
+			}
+
+			if ok, err := luhn.ValidateISO7064(full, tt.system); err != nil || !ok {
+				t.Errorf("ValidateISO7064(%q) = %v, %v, want true", full, ok, err)
+			}
+		})
+	}
+}
+
+// TestValidateISO7064 tests validation, including supplementary and
+// lowercase check characters.
+func TestValidateISO7064(t *testing.T) {
+	tests := []struct {
+		system luhn.ISO7064
+		input  string
+		want   bool
+	}{
+		{luhn.ISO7064Mod11_2, "000000021694233X", true},
+		{luhn.ISO7064Mod11_2, "000000021694233x", true},
+		{luhn.ISO7064Mod11_2, "0000000216942330", false},
+		{luhn.ISO7064Mod37_2, "g123498654321h", true},
+		{luhn.ISO7064Mod37_2, "G123498654321*", false},
+		{luhn.ISO7064Mod97_10, "79444", true},
+		{luhn.ISO7064Mod97_10, "79445", false},
+		{luhn.ISO7064Mod97_10, "79499", false},
+		{luhn.ISO7064Mod1271_36, "iso793w", true},
+		{luhn.ISO7064Mod11_10, "07945", true},
+		{luhn.ISO7064Mod11_10, "07954", false},
+		{luhn.ISO7064Mod37_36, "A12425GABC1234002M", true},
+		{luhn.ISO7064Mod37_36, "A12425GABC1234002N", false},
+	}
+
+	for _, tt := range tests {
+		got, err := luhn.ValidateISO7064(tt.input, tt.system)
+		if err != nil {
+			t.Fatalf("ValidateISO7064(%q, %v): unexpected error: %v", tt.input, tt.system, err)
+		}
+		if got != tt.want {
+			t.Errorf("ValidateISO7064(%q, %v) = %v, want %v", tt.input, tt.system, got, tt.want)
+		}
+	}
+}
+
+// TestISO7064_RoundTrip tests that generated values validate and that single
+// substitutions are detected.
+func TestISO7064_RoundTrip(t *testing.T) {
+	payloads := map[luhn.ISO7064]string{
+		luhn.ISO7064Mod11_2:    "123456789",
+		luhn.ISO7064Mod37_2:    "ABC123XYZ",
+		luhn.ISO7064Mod97_10:   "123456789",
+		luhn.ISO7064Mod661_26:  "HELLOWORLD",
+		luhn.ISO7064Mod1271_36: "ABC123XYZ",
+		luhn.ISO7064Mod11_10:   "123456789",
+		luhn.ISO7064Mod37_36:   "ABC123XYZ",
+	}
+
+	for system, payload := range payloads {
+		full, err := luhn.GenerateISO7064(payload, system, false)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", system, err)
+		}
+		alphabet := system.Alphabet().String()
+		for i := 0; i < len(payload); i++ {
+			for _, c := range []byte(alphabet) {
+				if c == full[i] {
+					continue
+				}
+				edited := []byte(full)
+				edited[i] = c
+				if ok, _ := luhn.ValidateISO7064(string(edited), system); ok {
+					t.Errorf("%v: ValidateISO7064(%q) = true for substitution in %q", system, edited, full)
+				}
+			}
+		}
+	}
+}
+
+// TestISO7064_Errors tests input validation.
+func TestISO7064_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func() error
+		wantErr   error
+		wantIndex int
+	}{
+		{"empty", func() error { _, err := luhn.GenerateISO7064("", luhn.ISO7064Mod11_2, false); return err }, luhn.ErrEmpty, -1},
+		{"letter in digits", func() error { _, err := luhn.GenerateISO7064("12A", luhn.ISO7064Mod97_10, false); return err }, luhn.ErrInvalidCharacter, 2},
+		{"digit in letters", func() error { _, err := luhn.GenerateISO7064("AB1", luhn.ISO7064Mod661_26, false); return err }, luhn.ErrInvalidCharacter, 2},
+		{"supplementary in payload", func() error { _, err := luhn.ValidateISO7064("12X34", luhn.ISO7064Mod11_2); return err }, luhn.ErrInvalidCharacter, 2},
+		{"bad check character", func() error { _, err := luhn.ValidateISO7064("1234*", luhn.ISO7064Mod11_2); return err }, luhn.ErrInvalidCharacter, 4},
+		{"spaces", func() error { _, err := luhn.ValidateISO7064("12 34", luhn.ISO7064Mod97_10); return err }, luhn.ErrSpaces, 2},
+		{"too short", func() error { _, err := luhn.ValidateISO7064("7", luhn.ISO7064Mod11_10); return err }, luhn.ErrMinLength, -1},
+		{"check only", func() error { _, err := luhn.ValidateISO7064("44", luhn.ISO7064Mod97_10); return err }, luhn.ErrCheckLength, -1},
+		{"unknown system", func() error { _, err := luhn.GenerateISO7064("1", luhn.ISO7064(99), false); return err }, luhn.ErrUnknownScheme, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn()
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if ve.Index != tt.wantIndex {
+				t.Errorf("Index = %d, want %d", ve.Index, tt.wantIndex)
+			}
+		})
+	}
+}
+
+// TestISO7064_Scheme tests the registered ISO 7064 schemes.
+func TestISO7064_Scheme(t *testing.T) {
+	s, err := luhn.Lookup("iso7064-mod-97-10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := s.Compute("794"); got != "44" {
+		t.Errorf("Compute = %q, want %q", got, "44")
+	}
+	if _, err := luhn.Lookup("iso7064-mod-37-36"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := luhn.ISO7064(0).String(); got != "ISO7064(0)" {
+		t.Errorf("String = %q", got)
+	}
+	if luhn.ISO7064(0).Alphabet() != nil {
+		t.Error("Alphabet of an unknown system is not nil")
+	}
+}
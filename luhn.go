@@ -3,54 +3,57 @@
 package luhn
 
 import (
-	"crypto/rand"
+	"bytes"
 	"crypto/subtle"
-	"errors"
-	"fmt"
-	"math/big"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 const codePoints = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
-var (
-	errEmpty         = errors.New("string cannot be empty")
-	errSpaces        = errors.New("string cannot contain spaces")
-	errNegative      = errors.New("negative numbers are not allowed")
-	errFloat         = errors.New("floating point numbers are not allowed")
-	errNotNumeric    = errors.New("string must be convertible to a number")
-	errMinLength     = errors.New("string must be longer than 1 character")
-	errRandomMax     = errors.New("string must be less than 100 characters")
-	errRandomMin     = errors.New("string must be greater than 1")
-	errInvalidN      = errors.New("n must be between 1 and 36")
-	errModNMaxLength = errors.New("string must be less than 10000 characters")
-)
+// text is the set of input types accepted by the internal validation and
+// checksum helpers, letting the string and []byte APIs share one implementation.
+type text interface {
+	string | []byte
+}
+
+// indexByte returns the index of the first c in s, or -1 if not present.
+func indexByte[T text](s T, c byte) int {
+	switch s := any(s).(type) {
+	case string:
+		return strings.IndexByte(s, c)
+	case []byte:
+		return bytes.IndexByte(s, c)
+	}
+	return -1
+}
 
-// validateInput applies shared input validation in spec order.
-func validateInput(value string) error {
-	if value == "" {
-		return errEmpty
+// validateInput applies shared input validation in spec order. fn names the
+// public function reported in any returned *ValidationError.
+func validateInput[T text](fn string, value T) error {
+	if len(value) == 0 {
+		return newValidationError(fn, ReasonEmpty, ErrEmpty, value, -1)
 	}
-	if strings.Contains(value, " ") {
-		return errSpaces
+	if i := indexByte(value, ' '); i >= 0 {
+		return newValidationError(fn, ReasonSpaces, ErrSpaces, value, i)
 	}
-	if strings.Contains(value, "-") {
-		return errNegative
+	if i := indexByte(value, '-'); i >= 0 {
+		return newValidationError(fn, ReasonNegative, ErrNegative, value, i)
 	}
-	if strings.Contains(value, ".") {
-		return errFloat
+	if i := indexByte(value, '.'); i >= 0 {
+		return newValidationError(fn, ReasonFloat, ErrFloat, value, i)
 	}
-	for _, c := range value {
-		if c < '0' || c > '9' {
-			return errNotNumeric
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return newValidationError(fn, ReasonNotNumeric, ErrNotNumeric, value, i)
 		}
 	}
 	return nil
 }
 
 // generateChecksum computes the Luhn check digit for a numeric string.
-func generateChecksum(value string) byte {
+func generateChecksum[T text](value T) byte {
 	sum := 0
 	shouldDouble := true
 
@@ -78,7 +81,12 @@ func generateChecksum(value string) byte {
 // If checksumOnly is true, only the check digit is returned.
 // Returns an error if value fails input validation.
 func Generate(value string, checksumOnly bool) (string, error) {
-	if err := validateInput(value); err != nil {
+	return generate("Generate", value, checksumOnly)
+}
+
+// generate implements Generate, reporting errors as coming from fn.
+func generate(fn, value string, checksumOnly bool) (string, error) {
+	if err := validateInput(fn, value); err != nil {
 		return "", err
 	}
 
@@ -92,15 +100,20 @@ func Generate(value string, checksumOnly bool) (string, error) {
 // Validate determines whether value has a valid Luhn check digit as its last character.
 // Returns an error if value fails input validation or has length 1.
 func Validate(value string) (bool, error) {
-	if err := validateInput(value); err != nil {
+	return validate("Validate", value)
+}
+
+// validate implements Validate, reporting errors as coming from fn.
+func validate(fn, value string) (bool, error) {
+	if err := validateInput(fn, value); err != nil {
 		return false, err
 	}
 	if len(value) == 1 {
-		return false, errMinLength
+		return false, newValidationError(fn, ReasonTooShort, ErrMinLength, value, -1)
 	}
 
 	payload := value[:len(value)-1]
-	generated, err := Generate(payload, false)
+	generated, err := generate(fn, payload, false)
 	if err != nil {
 		return false, err
 	}
@@ -113,174 +126,80 @@ func Validate(value string) (bool, error) {
 // with a valid Luhn check digit. The first digit is never zero.
 // Returns an error if length fails input validation or is out of range [2, 100].
 func Random(length string) (string, error) {
-	if err := validateInput(length); err != nil {
+	if err := validateInput("Random", length); err != nil {
 		return "", err
 	}
 	n, err := strconv.Atoi(length)
 	if err != nil {
 		// Overflow means the number is far greater than 100.
-		return "", errRandomMax
-	}
-	if n > 100 {
-		return "", errRandomMax
-	}
-	if n < 2 {
-		return "", errRandomMin
-	}
-
-	// Generate n-1 random digits (first digit 1-9, rest 0-9)
-	buf := make([]byte, n-1)
-	first, err := rand.Int(rand.Reader, big.NewInt(9))
-	if err != nil {
-		return "", err
+		return "", newValidationError("Random", ReasonOutOfRange, ErrRandomMax, length, -1)
 	}
-	buf[0] = byte('1' + first.Int64())
-	for i := 1; i < n-1; i++ {
-		d, err := rand.Int(rand.Reader, big.NewInt(10))
-		if err != nil {
-			return "", err
-		}
-		buf[i] = byte('0' + d.Int64())
-	}
-
-	// Append check digit via Generate
-	result, err := Generate(string(buf), false)
-	if err != nil {
-		return "", err
-	}
-	return result, nil
-}
-
-// validateModNInput validates input for mod-N functions.
-// Checks empty and spaces (shared with validateInput), then validates each
-// character against the CODE_POINTS alphabet for the given n.
-func validateModNInput(value string, n int) error {
-	if value == "" {
-		return errEmpty
-	}
-	if strings.Contains(value, " ") {
-		return errSpaces
-	}
-	for i := 0; i < len(value); i++ {
-		if charIndex(value[i], n) < 0 {
-			return fmt.Errorf("invalid character: %q", value[i])
-		}
-	}
-	return nil
+	return defaultGenerator.random("Random", n)
 }
 
-// charIndex returns the index of c in the CODE_POINTS alphabet, or -1 if not found.
-func charIndex(c byte, n int) int {
-	var idx int
-	if c >= '0' && c <= '9' {
-		idx = int(c - '0')
-	} else if c >= 'A' && c <= 'Z' {
-		idx = int(c-'A') + 10
-	} else if c >= 'a' && c <= 'z' {
-		idx = int(c-'a') + 10
-	} else {
-		return -1
-	}
-	if idx >= n {
-		return -1
-	}
-	return idx
-}
-
-// generateChecksumModN computes the Luhn mod-N check character index.
-func generateChecksumModN(value string, n int) (int, error) {
-	sum := 0
-	shouldDouble := true
-
-	for i := len(value) - 1; i >= 0; i-- {
-		idx := charIndex(value[i], n)
-		if idx < 0 {
-			return 0, fmt.Errorf("invalid character: %q", value[i])
-		}
-
-		if shouldDouble {
-			doubled := idx * 2
-			if doubled >= n {
-				sum += doubled/n + doubled%n
-			} else {
-				sum += doubled
-			}
-		} else {
-			sum += idx
-		}
-		shouldDouble = !shouldDouble
-	}
-
-	checkIdx := (n - (sum % n)) % n
-	return checkIdx, nil
+// RandomWithPrefix generates a random numeric string of the given length that
+// begins with prefix and ends with a valid Luhn check digit, drawing from
+// crypto/rand. See Generator.RandomWithPrefix.
+func RandomWithPrefix(prefix string, length int) (string, error) {
+	return defaultGenerator.randomWithPrefix("RandomWithPrefix", prefix, length)
 }
 
 // GenerateModN computes a Luhn mod-N check character for the given alphanumeric value.
 // n must be between 1 and 36. If checksumOnly is true, only the check character is returned.
-func GenerateModN(value string, n int, checksumOnly bool) (string, error) {
+// opts may normalize the input and select a CasePolicy; by default the check
+// character is uppercase.
+func GenerateModN(value string, n int, checksumOnly bool, opts ...Option) (string, error) {
 	if n < 1 || n > 36 {
-		return "", errInvalidN
+		return "", newValidationError("GenerateModN", ReasonInvalidN, ErrInvalidN, value, -1)
 	}
-	if err := validateModNInput(value, n); err != nil {
-		return "", err
-	}
-	if len(value) >= 10000 {
-		return "", errModNMaxLength
-	}
-
-	checkIdx, err := generateChecksumModN(value, n)
+	o := newOptions(opts)
+	a := codePointAlphabets[n]
+	value, err := o.prepareModN("GenerateModN", value, a)
 	if err != nil {
 		return "", err
 	}
 
-	checkChar := codePoints[checkIdx]
-	if checksumOnly {
-		return string(checkChar), nil
+	generated, err := a.generate("GenerateModN", value, checksumOnly)
+	if err != nil || o.casePolicy != CasePreserve {
+		return generated, err
 	}
-	return value + string(checkChar), nil
+	return preserveCase(value, generated), nil
 }
 
 // ValidateModN determines whether value has a valid Luhn mod-N check character.
-// n must be between 1 and 36.
-func ValidateModN(value string, n int) (bool, error) {
+// n must be between 1 and 36. By default letters are matched
+// case-insensitively; opts may normalize the input and select a CasePolicy.
+func ValidateModN(value string, n int, opts ...Option) (bool, error) {
 	if n < 1 || n > 36 {
-		return false, errInvalidN
+		return false, newValidationError("ValidateModN", ReasonInvalidN, ErrInvalidN, value, -1)
 	}
-	if err := validateModNInput(value, n); err != nil {
+	o := newOptions(opts)
+	a := codePointAlphabets[n]
+	value, err := o.prepareModN("ValidateModN", value, a)
+	if err != nil {
 		return false, err
 	}
-	if len(value) == 1 {
-		return false, errMinLength
-	}
-	if len(value) >= 10000 {
-		return false, errModNMaxLength
-	}
-
-	// Normalize to uppercase so that lowercase input matches the uppercase
-	// CODE_POINTS alphabet used by GenerateModN.
-	upper := strings.ToUpper(value)
 
-	payload := upper[:len(upper)-1]
-	generated, err := GenerateModN(payload, n, false)
-	if err != nil {
-		return false, err
+	valid, err := a.validate("ValidateModN", value)
+	if !valid || err != nil || o.casePolicy != CasePreserve {
+		return valid, err
 	}
-	// Use constant-time comparison to prevent timing side-channel attacks
-	// that could reveal information about valid check digits.
-	return subtle.ConstantTimeCompare([]byte(generated), []byte(upper)) == 1, nil
+	check, size := utf8.DecodeLastRuneInString(value)
+	return renderCheck(value[:len(value)-size], check) == check, nil
 }
 
 // ChecksumModN returns the integer index of the Luhn mod-N check character for value.
-// n must be between 1 and 36.
-func ChecksumModN(value string, n int) (int, error) {
+// n must be between 1 and 36. opts may normalize the input and select a
+// CasePolicy.
+func ChecksumModN(value string, n int, opts ...Option) (int, error) {
 	if n < 1 || n > 36 {
-		return 0, errInvalidN
+		return 0, newValidationError("ChecksumModN", ReasonInvalidN, ErrInvalidN, value, -1)
 	}
-	if err := validateModNInput(value, n); err != nil {
+	o := newOptions(opts)
+	a := codePointAlphabets[n]
+	value, err := o.prepareModN("ChecksumModN", value, a)
+	if err != nil {
 		return 0, err
 	}
-	if len(value) >= 10000 {
-		return 0, errModNMaxLength
-	}
-	return generateChecksumModN(value, n)
+	return a.checksumChecked("ChecksumModN", value)
 }
@@ -0,0 +1,21 @@
+package meid_test
+
+import (
+	"fmt"
+
+	"github.com/jrrembert/go-luhn/meid"
+)
+
+func ExampleParse() {
+	m, err := meid.Parse("A0000000002329")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(m.Hex(), m.HexCheckDigit())
+	fmt.Println(m.Decimal(), m.DecimalCheckDigit())
+	fmt.Println(m.PseudoESN())
+	// Output:
+	// A0000000002329 9
+	// 268435456000009001 3
+	// 8051F1AB
+}
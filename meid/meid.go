@@ -0,0 +1,217 @@
+// Package meid handles the MEID (Mobile Equipment Identifier) and ESN
+// (Electronic Serial Number) identifiers of CDMA devices.
+//
+// An MEID is 56 bits, usually written as 14 hexadecimal digits: an 8-digit
+// manufacturer code, whose first two digits are the region code, and a
+// 6-digit serial number. Its check digit is computed with the Luhn algorithm
+// over hexadecimal digits, i.e. luhn.GenerateModN with n=16. The decimal form
+// writes the manufacturer code as 10 decimal digits and the serial number as
+// 8, with an ordinary Luhn check digit.
+package meid
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// Lengths of the MEID and ESN forms, without check digits.
+const (
+	hexLength        = 14
+	decimalLength    = 18
+	esnHexLength     = 8
+	esnDecimalLength = 11
+)
+
+// MEID and ESN failures, reported in a *luhn.ValidationError with reason
+// luhn.ReasonLength, luhn.ReasonCheckDigit or luhn.ReasonOutOfRange.
+var (
+	ErrInvalidLength     = errors.New("invalid MEID or ESN length")
+	ErrInvalidCheckDigit = errors.New("invalid check digit")
+	ErrOutOfRange        = errors.New("decimal MEID or ESN field out of range")
+)
+
+// checkHex applies the luhn input checks for hexadecimal digits (in either
+// case) to s, reporting errors as coming from fn.
+func checkHex(fn, s string) error {
+	if _, err := luhn.GenerateModN(s, 16, true); err != nil {
+		return err.(*luhn.ValidationError).WithFunc(fn)
+	}
+	return nil
+}
+
+// MEID is a 56-bit Mobile Equipment Identifier.
+type MEID uint64
+
+// Parse parses an MEID in any of its forms, distinguished by length:
+//
+//   - 14 hexadecimal digits;
+//   - 15 hexadecimal digits, the last being the hexadecimal check digit;
+//   - 18 decimal digits;
+//   - 19 decimal digits, the last being the decimal check digit.
+//
+// Hexadecimal digits may be in either case. A check digit, if present, must
+// be valid. Input errors are those of luhn.Validate for the decimal forms and
+// luhn.GenerateModN otherwise, reported as coming from Parse.
+func Parse(s string) (MEID, error) {
+	switch len(s) {
+	case hexLength, hexLength + 1:
+		if err := checkHex("Parse", s); err != nil {
+			return 0, err
+		}
+		if len(s) == hexLength+1 {
+			if valid, _ := luhn.ValidateModN(s, 16); !valid {
+				return 0, luhn.NewValidationError("Parse", luhn.ReasonCheckDigit, ErrInvalidCheckDigit, "", -1)
+			}
+		}
+		v, _ := strconv.ParseUint(s[:hexLength], 16, 64)
+		return MEID(v), nil
+
+	case decimalLength, decimalLength + 1:
+		valid, err := luhn.Validate(s)
+		if err != nil {
+			return 0, err.(*luhn.ValidationError).WithFunc("Parse")
+		}
+		if len(s) == decimalLength+1 && !valid {
+			return 0, luhn.NewValidationError("Parse", luhn.ReasonCheckDigit, ErrInvalidCheckDigit, "", -1)
+		}
+		manufacturer, _ := strconv.ParseUint(s[:10], 10, 64)
+		serial, _ := strconv.ParseUint(s[10:decimalLength], 10, 64)
+		if manufacturer > 0xFFFFFFFF || serial > 0xFFFFFF {
+			return 0, luhn.NewValidationError("Parse", luhn.ReasonOutOfRange, ErrOutOfRange, "", -1)
+		}
+		return MEID(manufacturer<<24 | serial), nil
+	}
+
+	// Report input errors such as spaces before the length.
+	if err := checkHex("Parse", s); err != nil {
+		return 0, err
+	}
+	return 0, luhn.NewValidationError("Parse", luhn.ReasonLength, ErrInvalidLength, "", -1)
+}
+
+// Manufacturer returns the 32-bit manufacturer code, including the region
+// code.
+func (m MEID) Manufacturer() uint32 {
+	return uint32(m >> 24)
+}
+
+// RegionCode returns the 8-bit region code, the top of the manufacturer code.
+func (m MEID) RegionCode() uint8 {
+	return uint8(m >> 48)
+}
+
+// Serial returns the 24-bit serial number.
+func (m MEID) Serial() uint32 {
+	return uint32(m & 0xFFFFFF)
+}
+
+// Hex returns the 14-digit uppercase hexadecimal form.
+func (m MEID) Hex() string {
+	return fmt.Sprintf("%014X", uint64(m)&(1<<56-1))
+}
+
+// HexCheckDigit returns the hexadecimal check digit of the hexadecimal form.
+func (m MEID) HexCheckDigit() string {
+	// Hex always returns valid input for GenerateModN.
+	c, _ := luhn.GenerateModN(m.Hex(), 16, true)
+	return c
+}
+
+// Decimal returns the 18-digit decimal form.
+func (m MEID) Decimal() string {
+	return fmt.Sprintf("%010d%08d", m.Manufacturer(), m.Serial())
+}
+
+// DecimalCheckDigit returns the decimal check digit of the decimal form.
+func (m MEID) DecimalCheckDigit() string {
+	// Decimal always returns valid input for Generate.
+	c, _ := luhn.Generate(m.Decimal(), true)
+	return c
+}
+
+// String returns the hexadecimal form.
+func (m MEID) String() string {
+	return m.Hex()
+}
+
+// PseudoESN returns the pseudo-ESN (pESN) derived from the MEID: the
+// manufacturer code 0x80 followed by the 24 least significant bits of the
+// SHA-1 digest of the 56-bit MEID.
+func (m MEID) PseudoESN() ESN {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(m))
+	sum := sha1.Sum(b[1:])
+	return ESN(0x80<<24 | uint32(sum[17])<<16 | uint32(sum[18])<<8 | uint32(sum[19]))
+}
+
+// ESN is a 32-bit Electronic Serial Number.
+type ESN uint32
+
+// ParseESN parses an ESN as 8 hexadecimal digits (in either case) or as 11
+// decimal digits: a 3-digit manufacturer code and an 8-digit serial number.
+// ESNs have no check digit. Input errors are those of luhn.Generate for the
+// decimal form and luhn.GenerateModN otherwise, reported as coming from
+// ParseESN.
+func ParseESN(s string) (ESN, error) {
+	switch len(s) {
+	case esnHexLength:
+		if err := checkHex("ParseESN", s); err != nil {
+			return 0, err
+		}
+		v, _ := strconv.ParseUint(s, 16, 32)
+		return ESN(v), nil
+
+	case esnDecimalLength:
+		// Generate applies the luhn input checks; ESNs have no check digit.
+		if _, err := luhn.Generate(s, true); err != nil {
+			return 0, err.(*luhn.ValidationError).WithFunc("ParseESN")
+		}
+		manufacturer, _ := strconv.ParseUint(s[:3], 10, 32)
+		serial, _ := strconv.ParseUint(s[3:], 10, 32)
+		if manufacturer > 0xFF || serial > 0xFFFFFF {
+			return 0, luhn.NewValidationError("ParseESN", luhn.ReasonOutOfRange, ErrOutOfRange, "", -1)
+		}
+		return ESN(manufacturer<<24 | serial), nil
+	}
+
+	if err := checkHex("ParseESN", s); err != nil {
+		return 0, err
+	}
+	return 0, luhn.NewValidationError("ParseESN", luhn.ReasonLength, ErrInvalidLength, "", -1)
+}
+
+// Manufacturer returns the 8-bit manufacturer code. It is 0x80 for a
+// pseudo-ESN.
+func (e ESN) Manufacturer() uint8 {
+	return uint8(e >> 24)
+}
+
+// Serial returns the 24-bit serial number.
+func (e ESN) Serial() uint32 {
+	return uint32(e) & 0xFFFFFF
+}
+
+// IsPseudo reports whether e is a pseudo-ESN derived from an MEID.
+func (e ESN) IsPseudo() bool {
+	return e.Manufacturer() == 0x80
+}
+
+// Hex returns the 8-digit uppercase hexadecimal form.
+func (e ESN) Hex() string {
+	return fmt.Sprintf("%08X", uint32(e))
+}
+
+// Decimal returns the 11-digit decimal form.
+func (e ESN) Decimal() string {
+	return fmt.Sprintf("%03d%08d", e.Manufacturer(), e.Serial())
+}
+
+// String returns the hexadecimal form.
+func (e ESN) String() string {
+	return e.Hex()
+}
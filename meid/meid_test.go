@@ -0,0 +1,157 @@
+package meid_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+	"github.com/jrrembert/go-luhn/meid"
+)
+
+// TestParse tests that every form of an MEID parses to the same value.
+func TestParse(t *testing.T) {
+	for _, s := range []string{
+		"A0000000002329",
+		"a0000000002329",
+		"A00000000023299",
+		"268435456000009001",
+		"2684354560000090013",
+	} {
+		m, err := meid.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q): unexpected error: %v", s, err)
+		}
+		if m != 0xA0000000002329 {
+			t.Errorf("Parse(%q) = %X, want A0000000002329", s, uint64(m))
+		}
+	}
+}
+
+// TestMEID_Forms tests the conversions and check digits.
+func TestMEID_Forms(t *testing.T) {
+	tests := []struct {
+		hex, hexCheck, dec, decCheck, pesn string
+	}{
+		{"A0000000002329", "9", "268435456000009001", "3", "8051F1AB"},
+		{"A10000009296F2", "F", "270113177609606898", "", "8075B7ED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			m, err := meid.Parse(tt.hex)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := m.Hex(); got != tt.hex {
+				t.Errorf("Hex = %q, want %q", got, tt.hex)
+			}
+			if got := m.String(); got != tt.hex {
+				t.Errorf("String = %q, want %q", got, tt.hex)
+			}
+			if got := m.HexCheckDigit(); got != tt.hexCheck {
+				t.Errorf("HexCheckDigit = %q, want %q", got, tt.hexCheck)
+			}
+			if got := m.Decimal(); got != tt.dec {
+				t.Errorf("Decimal = %q, want %q", got, tt.dec)
+			}
+			want := tt.decCheck
+			if want == "" {
+				want, _ = luhn.Generate(tt.dec, true)
+			}
+			if got := m.DecimalCheckDigit(); got != want {
+				t.Errorf("DecimalCheckDigit = %q, want %q", got, want)
+			}
+			if got := m.PseudoESN().Hex(); got != tt.pesn {
+				t.Errorf("PseudoESN = %q, want %q", got, tt.pesn)
+			}
+
+			// The decimal form round-trips.
+			d, err := meid.Parse(m.Decimal() + m.DecimalCheckDigit())
+			if err != nil || d != m {
+				t.Errorf("Parse(decimal) = %X, %v", uint64(d), err)
+			}
+		})
+	}
+}
+
+// TestMEID_Fields tests the manufacturer, region and serial accessors.
+func TestMEID_Fields(t *testing.T) {
+	m := meid.MEID(0xA10000009296F2)
+	if m.Manufacturer() != 0xA1000000 || m.RegionCode() != 0xA1 || m.Serial() != 0x9296F2 {
+		t.Errorf("got %X %X %X", m.Manufacturer(), m.RegionCode(), m.Serial())
+	}
+}
+
+// TestParse_Errors tests the rejected inputs.
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   error
+		wantIndex int
+	}{
+		{"empty", "", luhn.ErrEmpty, -1},
+		{"spaces", "A0000000 002329", luhn.ErrSpaces, 8},
+		{"not hex", "A000000000232G", luhn.ErrInvalidCharacter, 13},
+		{"hex check digit", "A00000000023290", meid.ErrInvalidCheckDigit, -1},
+		{"decimal not numeric", "26843545600000900A", luhn.ErrNotNumeric, 17},
+		{"decimal check digit", "2684354560000090010", meid.ErrInvalidCheckDigit, -1},
+		{"manufacturer out of range", "999999999900000000", meid.ErrOutOfRange, -1},
+		{"serial out of range", "268435456099999999", meid.ErrOutOfRange, -1},
+		{"length", "A000000000232", meid.ErrInvalidLength, -1},
+		{"length not hex", "A0000000002x", luhn.ErrInvalidCharacter, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := meid.Parse(tt.input)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if ve.Func != "Parse" || ve.Index != tt.wantIndex {
+				t.Errorf("Func, Index = %q, %d, want Parse, %d", ve.Func, ve.Index, tt.wantIndex)
+			}
+			if ve.Index >= 0 && ve.Char != tt.input[ve.Index] {
+				t.Errorf("Char = %q, want %q", ve.Char, tt.input[ve.Index])
+			}
+		})
+	}
+}
+
+// TestParseESN tests the hexadecimal and decimal ESN forms.
+func TestParseESN(t *testing.T) {
+	for _, s := range []string{"8051F1AB", "8051f1ab", "12805370283"} {
+		e, err := meid.ParseESN(s)
+		if err != nil {
+			t.Fatalf("ParseESN(%q): unexpected error: %v", s, err)
+		}
+		if e != 0x8051F1AB {
+			t.Errorf("ParseESN(%q) = %X, want 8051F1AB", s, uint32(e))
+		}
+	}
+
+	e := meid.ESN(0x8051F1AB)
+	if e.Decimal() != "12805370283" || e.Manufacturer() != 0x80 || e.Serial() != 0x51F1AB || !e.IsPseudo() {
+		t.Errorf("got %s %X %X %v", e.Decimal(), e.Manufacturer(), e.Serial(), e.IsPseudo())
+	}
+	if meid.ESN(0x12345678).IsPseudo() {
+		t.Error("IsPseudo = true for a manufacturer ESN")
+	}
+
+	for _, tt := range []struct {
+		input   string
+		wantErr error
+	}{
+		{"8051F1AX", luhn.ErrInvalidCharacter},
+		{"1280537028x", luhn.ErrNotNumeric},
+		{"25600000000", meid.ErrOutOfRange},
+		{"8051F1A", meid.ErrInvalidLength},
+	} {
+		_, err := meid.ParseESN(tt.input)
+		var ve *luhn.ValidationError
+		if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) || ve.Func != "ParseESN" {
+			t.Errorf("ParseESN(%q) = %v, want %v from ParseESN", tt.input, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package luhn
+
+// npiPrefix is the card issuer identifier that precedes an NPI in the Luhn
+// computation: 80 for health applications and 840 for the United States.
+const npiPrefix = "80840"
+
+// Lengths of an NPI without its check digit, and of the card issuer form
+// that includes npiPrefix.
+const (
+	npiBodyLength   = 9
+	npiIssuerLength = len(npiPrefix) + npiBodyLength
+)
+
+// GenerateNPI calculates and appends the check digit of a US National
+// Provider Identifier to value, which must have 9 digits, or 14 in the card
+// issuer form starting with 80840. The first digit of the NPI itself must be
+// 1 or 2. If checksumOnly is true, only the check digit is returned.
+func GenerateNPI(value string, checksumOnly bool) (string, error) {
+	prefixed, err := npiPrefixed("GenerateNPI", value, false)
+	if err != nil {
+		return "", err
+	}
+
+	check := generateChecksum(prefixed)
+	if checksumOnly {
+		return string(check), nil
+	}
+	return value + string(check), nil
+}
+
+// ValidateNPI determines whether value is a US National Provider Identifier
+// with a valid check digit. value must have 10 digits, or 15 in the card
+// issuer form starting with 80840, and the first digit of the NPI itself must
+// be 1 or 2. The check digit is computed as if value were prefixed with 80840,
+// so a 10-digit NPI that passes ValidateNPI usually fails Validate.
+func ValidateNPI(value string) (bool, error) {
+	prefixed, err := npiPrefixed("ValidateNPI", value, true)
+	if err != nil {
+		return false, err
+	}
+	return validate("ValidateNPI", prefixed)
+}
+
+// npiPrefixed checks the length, issuer prefix and first digit of an NPI,
+// with its check digit if withCheck is true, and returns it in the card
+// issuer form. fn names the public function reported in any returned error.
+func npiPrefixed(fn, value string, withCheck bool) (string, error) {
+	if err := validateInput(fn, value); err != nil {
+		return "", err
+	}
+
+	extra := 0
+	if withCheck {
+		extra = 1
+	}
+	start := 0
+	switch len(value) - extra {
+	case npiBodyLength:
+	case npiIssuerLength:
+		for i := 0; i < len(npiPrefix); i++ {
+			if value[i] != npiPrefix[i] {
+				return "", newValidationError(fn, ReasonPrefix, ErrNPIPrefix, value, i)
+			}
+		}
+		start = len(npiPrefix)
+	default:
+		return "", newValidationError(fn, ReasonLength, ErrNPILength, value, -1)
+	}
+
+	if value[start] != '1' && value[start] != '2' {
+		return "", newValidationError(fn, ReasonFirstDigit, ErrNPIFirstDigit, value, start)
+	}
+	if start == 0 {
+		return npiPrefix + value, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,121 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestGenerateNPI tests NPI check digits in both the 10-digit and the card
+// issuer forms.
+func TestGenerateNPI(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"123456789", "1234567893"},
+		{"80840123456789", "808401234567893"},
+		{"223456789", "2234567891"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := luhn.GenerateNPI(tt.input, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateNPI(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			check, err := luhn.GenerateNPI(tt.input, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := tt.want[len(tt.input):]; check != want {
+				t.Errorf("GenerateNPI(%q, true) = %q, want %q", tt.input, check, want)
+			}
+		})
+	}
+}
+
+// TestValidateNPI tests NPI validation, including that the prefix is applied
+// where plain Validate gives the wrong answer.
+func TestValidateNPI(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"1234567893", true},
+		{"808401234567893", true},
+		{"2234567891", true},
+		{"1234567890", false},
+		{"1234567839", false},
+		{"808401234567890", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := luhn.ValidateNPI(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateNPI(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if valid, _ := luhn.Validate("1234567893"); valid {
+		t.Error("Validate(\"1234567893\") = true, want false without the prefix")
+	}
+}
+
+// TestNPI_Errors tests the length, prefix and first-digit rules.
+func TestNPI_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		fn         func(string) error
+		input      string
+		wantErr    error
+		wantReason luhn.Reason
+		wantIndex  int
+	}{
+		{"validate empty", validateNPI, "", luhn.ErrEmpty, luhn.ReasonEmpty, -1},
+		{"validate not numeric", validateNPI, "12345678A3", luhn.ErrNotNumeric, luhn.ReasonNotNumeric, 8},
+		{"validate short", validateNPI, "123456789", luhn.ErrNPILength, luhn.ReasonLength, -1},
+		{"validate between forms", validateNPI, "0840123456789", luhn.ErrNPILength, luhn.ReasonLength, -1},
+		{"validate long", validateNPI, "8084012345678930", luhn.ErrNPILength, luhn.ReasonLength, -1},
+		{"validate first digit", validateNPI, "3234567893", luhn.ErrNPIFirstDigit, luhn.ReasonFirstDigit, 0},
+		{"validate issuer first digit", validateNPI, "808400234567893", luhn.ErrNPIFirstDigit, luhn.ReasonFirstDigit, 5},
+		{"validate issuer prefix", validateNPI, "808411234567893", luhn.ErrNPIPrefix, luhn.ReasonPrefix, 4},
+		{"generate with check digit", generateNPI, "1234567893", luhn.ErrNPILength, luhn.ReasonLength, -1},
+		{"generate first digit", generateNPI, "023456789", luhn.ErrNPIFirstDigit, luhn.ReasonFirstDigit, 0},
+		{"generate issuer prefix", generateNPI, "90840123456789", luhn.ErrNPIPrefix, luhn.ReasonPrefix, 0},
+		{"generate issuer prefix last digit", generateNPI, "80841123456789", luhn.ErrNPIPrefix, luhn.ReasonPrefix, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn(tt.input)
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if ve.Reason != tt.wantReason || ve.Index != tt.wantIndex {
+				t.Errorf("Reason, Index = %q, %d, want %q, %d", ve.Reason, ve.Index, tt.wantReason, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func validateNPI(s string) error {
+	_, err := luhn.ValidateNPI(s)
+	return err
+}
+
+func generateNPI(s string) error {
+	_, err := luhn.GenerateNPI(s, false)
+	return err
+}
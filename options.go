@@ -0,0 +1,91 @@
+package luhn
+
+import "strings"
+
+// Option configures the input normalization applied by GenerateWith and
+// ValidateWith before the strict validation rules of Generate and Validate,
+// and by the mod-N functions GenerateModN, ValidateModN and ChecksumModN.
+type Option func(*options)
+
+type options struct {
+	trimSpace  bool
+	separators string
+	reject     string
+	casePolicy CasePolicy
+}
+
+// newOptions applies opts to a zero options value.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTrimSpace removes leading and trailing whitespace from the input.
+func WithTrimSpace() Option {
+	return func(o *options) {
+		o.trimSpace = true
+	}
+}
+
+// WithSeparators removes every occurrence of the given characters from the
+// input, e.g. WithSeparators(" -") accepts "4111 1111-1111 1111".
+func WithSeparators(chars string) Option {
+	return func(o *options) {
+		o.separators += chars
+	}
+}
+
+// WithRejectChars rejects input containing any of the given characters with
+// ErrInvalidCharacter, even if they are also listed as separators.
+func WithRejectChars(chars string) Option {
+	return func(o *options) {
+		o.reject += chars
+	}
+}
+
+// normalize applies the options to value in a fixed order: trim, reject, then
+// strip separators. fn names the public function reported in any returned
+// error.
+func (o options) normalize(fn, value string) (string, error) {
+	if o.trimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if o.reject != "" {
+		if i := strings.IndexAny(value, o.reject); i >= 0 {
+			return "", newValidationError(fn, ReasonInvalidCharacter, ErrInvalidCharacter, value, i)
+		}
+	}
+	if o.separators != "" {
+		value = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(o.separators, r) {
+				return -1
+			}
+			return r
+		}, value)
+	}
+	return value, nil
+}
+
+// GenerateWith normalizes value according to opts and then behaves like
+// Generate. The returned string is built from the normalized value, so
+// stripped separators do not appear in it.
+func GenerateWith(value string, checksumOnly bool, opts ...Option) (string, error) {
+	normalized, err := newOptions(opts).normalize("GenerateWith", value)
+	if err != nil {
+		return "", err
+	}
+	return generate("GenerateWith", normalized, checksumOnly)
+}
+
+// ValidateWith normalizes value according to opts and then behaves like
+// Validate. Error indexes refer to the normalized value.
+func ValidateWith(value string, opts ...Option) (bool, error) {
+	normalized, err := newOptions(opts).normalize("ValidateWith", value)
+	if err != nil {
+		return false, err
+	}
+	return validate("ValidateWith", normalized)
+}
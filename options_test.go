@@ -0,0 +1,85 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestValidateWith tests ValidateWith against formatted card numbers.
+func TestValidateWith(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  []luhn.Option
+		want  bool
+	}{
+		{"no options", "4111111111111111", nil, true},
+		{"spaces", "4111 1111 1111 1111", []luhn.Option{luhn.WithSeparators(" ")}, true},
+		{"dashes", "4111-1111-1111-1111", []luhn.Option{luhn.WithSeparators("-")}, true},
+		{"mixed separators", "4111 1111-1111 1111", []luhn.Option{luhn.WithSeparators(" -")}, true},
+		{"trim", "\t4111111111111111\n", []luhn.Option{luhn.WithTrimSpace()}, true},
+		{"invalid check digit", "4111 1111 1111 1112", []luhn.Option{luhn.WithSeparators(" ")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := luhn.ValidateWith(tt.input, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateWith(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateWith tests that GenerateWith returns the normalized value plus check digit.
+func TestGenerateWith(t *testing.T) {
+	got, err := luhn.GenerateWith(" 7992-7398-71 ", false, luhn.WithTrimSpace(), luhn.WithSeparators("-"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "79927398713"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got, err = luhn.GenerateWith("7992 7398 71", true, luhn.WithSeparators(" "))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestOptionsErrors verifies that normalized input still goes through strict validation.
+func TestOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  []luhn.Option
+		want  error
+	}{
+		{"strict without options", "4111 1111", nil, luhn.ErrSpaces},
+		{"only separators", " - ", []luhn.Option{luhn.WithSeparators(" -")}, luhn.ErrEmpty},
+		{"rejected char", "4111/1111", []luhn.Option{luhn.WithRejectChars("/")}, luhn.ErrInvalidCharacter},
+		{"reject wins over separator", "4111-1111", []luhn.Option{luhn.WithSeparators("-"), luhn.WithRejectChars("-")}, luhn.ErrInvalidCharacter},
+		{"unlisted separator", "4111_1111", []luhn.Option{luhn.WithSeparators(" ")}, luhn.ErrNotNumeric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := luhn.ValidateWith(tt.input, tt.opts...)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			var verr *luhn.ValidationError
+			if !errors.As(err, &verr) || verr.Func != "ValidateWith" {
+				t.Errorf("expected *ValidationError from ValidateWith, got %#v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package luhn
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Scheme is a check character algorithm. Implementations let callers select
+// an algorithm at run time, e.g. by name from configuration via Lookup. The
+// built-in implementations report errors from these methods with Func set to
+// "Scheme.Compute", "Scheme.Generate" or "Scheme.Validate".
+type Scheme interface {
+	// Compute returns the check characters for value.
+	Compute(value string) (string, error)
+	// Generate returns value followed by its check characters.
+	Generate(value string) (string, error)
+	// Validate reports whether value ends with valid check characters.
+	Validate(value string) (bool, error)
+	// Alphabet returns the characters accepted in the payload.
+	Alphabet() *Alphabet
+}
+
+// Luhn is the standard mod-10 Luhn scheme, backed by Generate and Validate.
+// It is registered as "luhn".
+var Luhn Scheme = luhnScheme{}
+
+type luhnScheme struct{}
+
+func (luhnScheme) Compute(value string) (string, error) {
+	return generate("Scheme.Compute", value, true)
+}
+
+func (luhnScheme) Generate(value string) (string, error) {
+	return generate("Scheme.Generate", value, false)
+}
+
+func (luhnScheme) Validate(value string) (bool, error) {
+	return validate("Scheme.Validate", value)
+}
+
+func (luhnScheme) Alphabet() *Alphabet {
+	return codePointAlphabets[10]
+}
+
+// LuhnModN returns the Luhn mod-N scheme over the first n characters of the
+// CODE_POINTS alphabet, as used by GenerateModN and ValidateModN. n must be
+// between 1 and 36. It is registered as "luhn-mod-<n>", e.g. "luhn-mod-36".
+func LuhnModN(n int) (Scheme, error) {
+	if n < 1 || n > 36 {
+		return nil, newValidationError("LuhnModN", ReasonInvalidN, ErrInvalidN, "", -1)
+	}
+	return NewLuhnScheme(codePointAlphabets[n]), nil
+}
+
+// NewLuhnScheme returns the Luhn mod-N scheme over a, backed by the methods
+// of a.
+func NewLuhnScheme(a *Alphabet) Scheme {
+	return alphabetScheme{a}
+}
+
+type alphabetScheme struct {
+	a *Alphabet
+}
+
+func (s alphabetScheme) Compute(value string) (string, error) {
+	return s.a.generate("Scheme.Compute", value, true)
+}
+
+func (s alphabetScheme) Generate(value string) (string, error) {
+	return s.a.generate("Scheme.Generate", value, false)
+}
+
+func (s alphabetScheme) Validate(value string) (bool, error) {
+	return s.a.validate("Scheme.Validate", value)
+}
+
+func (s alphabetScheme) Alphabet() *Alphabet {
+	return s.a
+}
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = map[string]Scheme{}
+)
+
+func init() {
+	Register("luhn", Luhn)
+	Register("verhoeff", Verhoeff)
+	Register("damm", dammDecimal)
+	for s := ISO7064Mod11_2; s <= ISO7064Mod37_36; s++ {
+		Register(iso7064Systems[s].key, s)
+	}
+	for n := 1; n <= 36; n++ {
+		Register("luhn-mod-"+strconv.Itoa(n), NewLuhnScheme(codePointAlphabets[n]))
+	}
+}
+
+// Register makes a scheme available by name to Lookup. It panics if name is
+// empty, s is nil, or a scheme is already registered under name.
+func Register(name string, s Scheme) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	if name == "" {
+		panic("luhn: Register with empty name")
+	}
+	if s == nil {
+		panic("luhn: Register scheme is nil")
+	}
+	if _, dup := schemes[name]; dup {
+		panic("luhn: Register called twice for scheme " + name)
+	}
+	schemes[name] = s
+}
+
+// Lookup returns the scheme registered under name. Returns ErrUnknownScheme
+// if there is none.
+func Lookup(name string) (Scheme, error) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	s, ok := schemes[name]
+	if !ok {
+		return nil, newValidationError("Lookup", ReasonUnknownScheme, ErrUnknownScheme, name, -1)
+	}
+	return s, nil
+}
+
+// Schemes returns the names of the registered schemes in sorted order.
+func Schemes() []string {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,137 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestLookup tests that the built-in schemes are registered and wrap the
+// package-level functions.
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{"luhn", "7992739871", "79927398713"},
+		{"luhn-mod-10", "7992739871", "79927398713"},
+		{"luhn-mod-36", "hello", "helloJ"},
+		{"luhn-mod-16", "FF", "FF2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := luhn.Lookup(tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := s.Generate(tt.payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Generate(%q) = %q, want %q", tt.payload, got, tt.want)
+			}
+			check, err := s.Compute(tt.payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if check != tt.want[len(tt.payload):] {
+				t.Errorf("Compute(%q) = %q, want %q", tt.payload, check, tt.want[len(tt.payload):])
+			}
+			if ok, err := s.Validate(got); err != nil || !ok {
+				t.Errorf("Validate(%q) = %v, %v, want true", got, ok, err)
+			}
+		})
+	}
+}
+
+// TestLookup_Unknown tests the error for an unregistered name.
+func TestLookup_Unknown(t *testing.T) {
+	_, err := luhn.Lookup("luhn-mod-37")
+	var ve *luhn.ValidationError
+	if !errors.As(err, &ve) || !errors.Is(err, luhn.ErrUnknownScheme) {
+		t.Fatalf("got %v, want ErrUnknownScheme", err)
+	}
+	if ve.Func != "Lookup" || ve.Reason != luhn.ReasonUnknownScheme {
+		t.Errorf("got Func=%q Reason=%q", ve.Func, ve.Reason)
+	}
+}
+
+// TestScheme_Errors tests that schemes report the usual validation errors.
+func TestScheme_Errors(t *testing.T) {
+	if _, err := luhn.Luhn.Generate("12a"); !errors.Is(err, luhn.ErrNotNumeric) {
+		t.Errorf("got %v, want ErrNotNumeric", err)
+	}
+	s, _ := luhn.Lookup("luhn-mod-36")
+	if _, err := s.Validate("A"); !errors.Is(err, luhn.ErrMinLength) {
+		t.Errorf("got %v, want ErrMinLength", err)
+	}
+	if _, err := luhn.LuhnModN(0); !errors.Is(err, luhn.ErrInvalidN) {
+		t.Errorf("got %v, want ErrInvalidN", err)
+	}
+
+	// Every built-in scheme reports its errors under the Scheme method names.
+	for _, name := range luhn.Schemes() {
+		s, _ := luhn.Lookup(name)
+		var ve *luhn.ValidationError
+		if _, err := s.Compute(""); !errors.As(err, &ve) || ve.Func != "Scheme.Compute" {
+			t.Errorf("%s: Compute error %v", name, err)
+		}
+		if _, err := s.Generate(""); !errors.As(err, &ve) || ve.Func != "Scheme.Generate" {
+			t.Errorf("%s: Generate error %v", name, err)
+		}
+		if _, err := s.Validate(""); !errors.As(err, &ve) || ve.Func != "Scheme.Validate" {
+			t.Errorf("%s: Validate error %v", name, err)
+		}
+	}
+}
+
+// TestScheme_Alphabet tests the alphabets of the built-in schemes.
+func TestScheme_Alphabet(t *testing.T) {
+	if got := luhn.Luhn.Alphabet().String(); got != "0123456789" {
+		t.Errorf("Luhn alphabet = %q", got)
+	}
+	s, _ := luhn.LuhnModN(36)
+	if s.Alphabet().Len() != 36 {
+		t.Errorf("luhn-mod-36 alphabet length = %d", s.Alphabet().Len())
+	}
+	if got := luhn.NewLuhnScheme(luhn.Base58).Alphabet(); got != luhn.Base58 {
+		t.Errorf("NewLuhnScheme alphabet = %v", got)
+	}
+}
+
+// TestRegister tests registering a custom scheme and the panics on misuse.
+func TestRegister(t *testing.T) {
+	// The registry is global, so register only once under -count.
+	if _, err := luhn.Lookup("test-crockford32"); err != nil {
+		luhn.Register("test-crockford32", luhn.NewLuhnScheme(luhn.Crockford32))
+	}
+	s, err := luhn.Lookup("test-crockford32")
+	if err != nil || s.Alphabet() != luhn.Crockford32 {
+		t.Fatalf("Lookup = %v, %v", s, err)
+	}
+
+	found := false
+	for _, name := range luhn.Schemes() {
+		if name == "test-crockford32" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Schemes does not include test-crockford32")
+	}
+
+	for name, s := range map[string]luhn.Scheme{"luhn": luhn.Luhn, "": luhn.Luhn, "test-nil": nil} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", name)
+				}
+			}()
+			luhn.Register(name, s)
+		}()
+	}
+}
@@ -0,0 +1,129 @@
+package luhn
+
+import "strings"
+
+// EditKind identifies the typo that a Suggestion corrects.
+type EditKind int
+
+const (
+	// Transposition swaps two adjacent characters.
+	Transposition EditKind = iota
+	// Substitution replaces a single character.
+	Substitution
+)
+
+// String returns "transposition" or "substitution".
+func (k EditKind) String() string {
+	if k == Transposition {
+		return "transposition"
+	}
+	return "substitution"
+}
+
+// Suggestion is a valid value reachable from an invalid one by a single edit.
+type Suggestion struct {
+	// Value is the corrected value.
+	Value string
+	// Kind is the edit that produces Value.
+	Kind EditKind
+	// Index is the position, in characters, of the substituted character or
+	// of the left character of the transposed pair.
+	Index int
+}
+
+// Suggest returns the values with a valid Luhn check digit that are reachable
+// from value by substituting a single digit or transposing two adjacent
+// digits. If value is already valid, Suggest returns nil.
+//
+// Suggestions are ranked with substitutions first, since single-digit errors
+// are by far the most common keying error, then transpositions; within each
+// kind, edits closer to the start of value come first.
+func Suggest(value string) ([]Suggestion, error) {
+	if err := validateInput("Suggest", value); err != nil {
+		return nil, err
+	}
+	if len(value) == 1 {
+		return nil, newValidationError("Suggest", ReasonTooShort, ErrMinLength, value, -1)
+	}
+	return suggest(value, codePointAlphabets[10]), nil
+}
+
+// SuggestModN is like Suggest for the Luhn mod-N algorithm over the
+// CODE_POINTS alphabet. n must be between 1 and 36. Input is compared
+// case-insensitively and suggestions are returned in uppercase.
+func SuggestModN(value string, n int) ([]Suggestion, error) {
+	if n < 1 || n > 36 {
+		return nil, newValidationError("SuggestModN", ReasonInvalidN, ErrInvalidN, value, -1)
+	}
+	return codePointAlphabets[n].suggestChecked("SuggestModN", strings.ToUpper(value))
+}
+
+// Suggest is like Suggest for the Luhn mod-N algorithm over the alphabet.
+// Substituted characters are rendered from the alphabet.
+func (a *Alphabet) Suggest(value string) ([]Suggestion, error) {
+	return a.suggestChecked("Alphabet.Suggest", value)
+}
+
+// suggestChecked implements Alphabet.Suggest, reporting errors as coming from fn.
+func (a *Alphabet) suggestChecked(fn, value string) ([]Suggestion, error) {
+	count, err := a.validateInput(fn, value)
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		return nil, newValidationError(fn, ReasonTooShort, ErrMinLength, value, -1)
+	}
+	if count >= modNMaxLength {
+		return nil, newValidationError(fn, ReasonTooLong, ErrModNMaxLength, value, -1)
+	}
+	return suggest(value, a), nil
+}
+
+// suggest computes the suggestions, in ranked order, for a value that has
+// passed input validation. It works on the sum of the whole value, including
+// the check character, which is a multiple of N exactly when the value is
+// valid. Each edit changes only the contributions of the edited positions.
+func suggest(value string, a *Alphabet) []Suggestion {
+	chars := []rune(value)
+	n := a.Len()
+	last := len(chars) - 1
+	idx := make([]int, len(chars))
+	contrib := make([]int, len(chars))
+	sum := 0
+	for i, r := range chars {
+		idx[i] = a.IndexRune(r)
+		contrib[i] = contribution(idx[i], n, (last-i)%2 == 1)
+		sum += contrib[i]
+	}
+	if sum%n == 0 {
+		return nil
+	}
+
+	var out []Suggestion
+	for i := range chars {
+		for c := 0; c < n; c++ {
+			if c == idx[i] {
+				continue
+			}
+			if (sum-contrib[i]+contribution(c, n, (last-i)%2 == 1))%n == 0 {
+				edited := append([]rune(nil), chars...)
+				edited[i] = a.runes[c]
+				out = append(out, Suggestion{Value: string(edited), Kind: Substitution, Index: i})
+			}
+		}
+	}
+	for i := 0; i < last; i++ {
+		if idx[i] == idx[i+1] {
+			continue
+		}
+		s := sum - contrib[i] - contrib[i+1] +
+			contribution(idx[i+1], n, (last-i)%2 == 1) +
+			contribution(idx[i], n, (last-i-1)%2 == 1)
+		if s%n == 0 {
+			edited := append([]rune(nil), chars...)
+			edited[i], edited[i+1] = edited[i+1], edited[i]
+			out = append(out, Suggestion{Value: string(edited), Kind: Transposition, Index: i})
+		}
+	}
+	return out
+}
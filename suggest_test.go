@@ -0,0 +1,158 @@
+package luhn_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// bruteForceSuggest enumerates every single-edit candidate of value and keeps
+// those accepted by validate.
+func bruteForceSuggest(value, alphabet string, validate func(string) bool) map[string]bool {
+	out := make(map[string]bool)
+	for i := 0; i+1 < len(value); i++ {
+		if value[i] == value[i+1] {
+			continue
+		}
+		b := []byte(value)
+		b[i], b[i+1] = b[i+1], b[i]
+		if validate(string(b)) {
+			out[string(b)] = true
+		}
+	}
+	for i := range value {
+		for j := 0; j < len(alphabet); j++ {
+			if alphabet[j] == value[i] {
+				continue
+			}
+			b := []byte(value)
+			b[i] = alphabet[j]
+			if validate(string(b)) {
+				out[string(b)] = true
+			}
+		}
+	}
+	return out
+}
+
+// TestSuggest verifies Suggest against a brute-force search.
+func TestSuggest(t *testing.T) {
+	inputs := []string{"79927398710", "79927398731", "4111111111111112", "10", "1232"}
+	validate := func(s string) bool {
+		ok, _ := luhn.Validate(s)
+		return ok
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			got, err := luhn.Suggest(input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := bruteForceSuggest(input, "0123456789", validate)
+			if len(got) != len(want) {
+				t.Errorf("got %d suggestions, want %d", len(got), len(want))
+			}
+			for _, s := range got {
+				if !want[s.Value] {
+					t.Errorf("unexpected suggestion %+v", s)
+				}
+			}
+		})
+	}
+}
+
+// TestSuggestRanking verifies that substitutions are ranked before transpositions.
+func TestSuggestRanking(t *testing.T) {
+	// "79927398731" is "79927398713" with the last two digits transposed.
+	got, err := luhn.Suggest("79927398731")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("expected suggestions, got none")
+	}
+	seenTransposition := false
+	for _, s := range got {
+		if s.Kind == luhn.Transposition {
+			seenTransposition = true
+		} else if seenTransposition {
+			t.Errorf("substitution %+v ranked after a transposition", s)
+		}
+	}
+	found := false
+	for _, s := range got {
+		if s.Value == "79927398713" && s.Kind == luhn.Transposition && s.Index == 9 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected transposition at index 9 to 79927398713, got %+v", got)
+	}
+}
+
+// TestSuggestValid verifies that a valid value produces no suggestions.
+func TestSuggestValid(t *testing.T) {
+	got, err := luhn.Suggest("79927398713")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("got %+v, want nil", got)
+	}
+}
+
+// TestSuggestModN verifies SuggestModN against a brute-force search.
+func TestSuggestModN(t *testing.T) {
+	tests := []struct {
+		input string
+		n     int
+	}{
+		{"HELLOA", 36},
+		{"hellOA", 36},
+		{"FF0", 16},
+		{"79927398710", 10},
+		{"2101", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := luhn.SuggestModN(tt.input, tt.n)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			validate := func(s string) bool {
+				ok, _ := luhn.ValidateModN(s, tt.n)
+				return ok
+			}
+			alphabet := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"[:tt.n]
+			want := bruteForceSuggest(strings.ToUpper(tt.input), alphabet, validate)
+			if len(got) != len(want) {
+				t.Errorf("got %d suggestions, want %d", len(got), len(want))
+			}
+			for _, s := range got {
+				if !want[s.Value] {
+					t.Errorf("unexpected suggestion %+v", s)
+				}
+			}
+		})
+	}
+}
+
+// TestSuggestErrors tests input validation.
+func TestSuggestErrors(t *testing.T) {
+	if _, err := luhn.Suggest("1"); !errors.Is(err, luhn.ErrMinLength) {
+		t.Errorf("Suggest(\"1\"): got %v, want %v", err, luhn.ErrMinLength)
+	}
+	if _, err := luhn.Suggest("12a"); !errors.Is(err, luhn.ErrNotNumeric) {
+		t.Errorf("Suggest(\"12a\"): got %v, want %v", err, luhn.ErrNotNumeric)
+	}
+	if _, err := luhn.SuggestModN("AB", 37); !errors.Is(err, luhn.ErrInvalidN) {
+		t.Errorf("SuggestModN n=37: got %v, want %v", err, luhn.ErrInvalidN)
+	}
+	if _, err := luhn.SuggestModN("A!", 36); !errors.Is(err, luhn.ErrInvalidCharacter) {
+		t.Errorf("SuggestModN(\"A!\"): got %v, want %v", err, luhn.ErrInvalidCharacter)
+	}
+}
@@ -0,0 +1,115 @@
+package luhn
+
+// verhoeffD is the multiplication table of the dihedral group D5.
+var verhoeffD = [10][10]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+	{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+	{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+	{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+	{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+	{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+	{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+	{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+// verhoeffP is the permutation table, applied once per position.
+var verhoeffP = [8][10]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+	{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+	{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+	{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+	{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+	{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+	{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+}
+
+// verhoeffInv holds the inverse of each element of D5.
+var verhoeffInv = [10]byte{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
+
+// verhoeffSum returns the Verhoeff checksum state of a numeric string. If
+// payload is true, positions are counted as if a check digit followed value.
+func verhoeffSum[T text](value T, payload bool) byte {
+	var c byte
+	pos := 0
+	if payload {
+		pos = 1
+	}
+	for i := len(value) - 1; i >= 0; i-- {
+		c = verhoeffD[c][verhoeffP[pos%8][value[i]-'0']]
+		pos++
+	}
+	return c
+}
+
+// GenerateVerhoeff calculates and appends a Verhoeff check digit to value.
+// If checksumOnly is true, only the check digit is returned. Input is
+// validated as by Generate.
+func GenerateVerhoeff(value string, checksumOnly bool) (string, error) {
+	return generateVerhoeff("GenerateVerhoeff", value, checksumOnly)
+}
+
+// generateVerhoeff implements GenerateVerhoeff, reporting errors as coming
+// from fn.
+func generateVerhoeff(fn, value string, checksumOnly bool) (string, error) {
+	if err := validateInput(fn, value); err != nil {
+		return "", err
+	}
+
+	check := '0' + verhoeffInv[verhoeffSum(value, true)]
+	if checksumOnly {
+		return string(check), nil
+	}
+	return value + string(check), nil
+}
+
+// ValidateVerhoeff determines whether value has a valid Verhoeff check digit
+// as its last character. Returns an error if value fails input validation or
+// has length 1.
+func ValidateVerhoeff(value string) (bool, error) {
+	return validateVerhoeff("ValidateVerhoeff", value)
+}
+
+// validateVerhoeff implements ValidateVerhoeff, reporting errors as coming
+// from fn.
+func validateVerhoeff(fn, value string) (bool, error) {
+	if err := validateInput(fn, value); err != nil {
+		return false, err
+	}
+	if len(value) == 1 {
+		return false, newValidationError(fn, ReasonTooShort, ErrMinLength, value, -1)
+	}
+	return verhoeffSum(value, false) == 0, nil
+}
+
+// ChecksumVerhoeff returns the Verhoeff check digit for value as an integer.
+func ChecksumVerhoeff(value string) (int, error) {
+	if err := validateInput("ChecksumVerhoeff", value); err != nil {
+		return 0, err
+	}
+	return int(verhoeffInv[verhoeffSum(value, true)]), nil
+}
+
+// Verhoeff is the Verhoeff scheme, backed by GenerateVerhoeff and
+// ValidateVerhoeff. It is registered as "verhoeff".
+var Verhoeff Scheme = verhoeffScheme{}
+
+type verhoeffScheme struct{}
+
+func (verhoeffScheme) Compute(value string) (string, error) {
+	return generateVerhoeff("Scheme.Compute", value, true)
+}
+
+func (verhoeffScheme) Generate(value string) (string, error) {
+	return generateVerhoeff("Scheme.Generate", value, false)
+}
+
+func (verhoeffScheme) Validate(value string) (bool, error) {
+	return validateVerhoeff("Scheme.Validate", value)
+}
+
+func (verhoeffScheme) Alphabet() *Alphabet {
+	return codePointAlphabets[10]
+}
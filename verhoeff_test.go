@@ -0,0 +1,148 @@
+package luhn_test
+
+import (
+	"errors"
+	"testing"
+
+	luhn "github.com/jrrembert/go-luhn"
+)
+
+// TestGenerateVerhoeff tests Verhoeff check digits against published examples.
+func TestGenerateVerhoeff(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"236", "2363"},
+		{"12345", "123451"},
+		{"142857", "1428570"},
+		{"0", "04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := luhn.GenerateVerhoeff(tt.input, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateVerhoeff(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			check, err := luhn.GenerateVerhoeff(tt.input, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := tt.want[len(tt.input):]; check != want {
+				t.Errorf("GenerateVerhoeff(%q, true) = %q, want %q", tt.input, check, want)
+			}
+
+			n, err := luhn.ChecksumVerhoeff(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := int(tt.want[len(tt.want)-1] - '0'); n != want {
+				t.Errorf("ChecksumVerhoeff(%q) = %d, want %d", tt.input, n, want)
+			}
+		})
+	}
+}
+
+// TestValidateVerhoeff tests Verhoeff validation.
+func TestValidateVerhoeff(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"2363", true},
+		{"123451", true},
+		{"2364", false},
+		{"2336", false},
+		// Luhn misses the 09 <-> 90 transposition; Verhoeff does not.
+		{"1090" + mustVerhoeff(t, "1090")[4:], true},
+		{"1900" + mustVerhoeff(t, "1090")[4:], false},
+	}
+
+	for _, tt := range tests {
+		got, err := luhn.ValidateVerhoeff(tt.input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("ValidateVerhoeff(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// mustVerhoeff returns value with its Verhoeff check digit.
+func mustVerhoeff(t *testing.T, value string) string {
+	t.Helper()
+	v, err := luhn.GenerateVerhoeff(value, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return v
+}
+
+// TestVerhoeff_DetectsAllTranspositions tests that every adjacent
+// transposition of a valid value is rejected.
+func TestVerhoeff_DetectsAllTranspositions(t *testing.T) {
+	for a := byte('0'); a <= '9'; a++ {
+		for b := byte('0'); b <= '9'; b++ {
+			if a == b {
+				continue
+			}
+			value := mustVerhoeff(t, string([]byte{'7', a, b, '3'}))
+			swapped := string([]byte{'7', b, a, '3'}) + value[4:]
+			if ok, _ := luhn.ValidateVerhoeff(swapped); ok {
+				t.Errorf("ValidateVerhoeff(%q) = true for transposition of %q", swapped, value)
+			}
+		}
+	}
+}
+
+// TestVerhoeff_Errors tests that Verhoeff shares the Luhn input validation.
+func TestVerhoeff_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr error
+		wantFn  string
+	}{
+		{"generate empty", func() error { _, err := luhn.GenerateVerhoeff("", false); return err }, luhn.ErrEmpty, "GenerateVerhoeff"},
+		{"generate spaces", func() error { _, err := luhn.GenerateVerhoeff("1 2", false); return err }, luhn.ErrSpaces, "GenerateVerhoeff"},
+		{"generate negative", func() error { _, err := luhn.GenerateVerhoeff("-12", false); return err }, luhn.ErrNegative, "GenerateVerhoeff"},
+		{"validate float", func() error { _, err := luhn.ValidateVerhoeff("1.2"); return err }, luhn.ErrFloat, "ValidateVerhoeff"},
+		{"validate not numeric", func() error { _, err := luhn.ValidateVerhoeff("12a"); return err }, luhn.ErrNotNumeric, "ValidateVerhoeff"},
+		{"validate too short", func() error { _, err := luhn.ValidateVerhoeff("5"); return err }, luhn.ErrMinLength, "ValidateVerhoeff"},
+		{"checksum not numeric", func() error { _, err := luhn.ChecksumVerhoeff("x"); return err }, luhn.ErrNotNumeric, "ChecksumVerhoeff"},
+		{"scheme", func() error { _, err := luhn.Verhoeff.Validate("x1"); return err }, luhn.ErrNotNumeric, "Scheme.Validate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn()
+			var ve *luhn.ValidationError
+			if !errors.As(err, &ve) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if ve.Func != tt.wantFn {
+				t.Errorf("Func = %q, want %q", ve.Func, tt.wantFn)
+			}
+		})
+	}
+}
+
+// TestVerhoeff_Scheme tests the registered Verhoeff scheme.
+func TestVerhoeff_Scheme(t *testing.T) {
+	s, err := luhn.Lookup("verhoeff")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := s.Generate("236"); got != "2363" {
+		t.Errorf("Generate = %q, want %q", got, "2363")
+	}
+	if got, _ := s.Compute("236"); got != "3" {
+		t.Errorf("Compute = %q, want %q", got, "3")
+	}
+}